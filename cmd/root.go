@@ -1,8 +1,11 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/hanofzelbri/middleware-generator/interfaces"
 
@@ -18,47 +21,168 @@ var rootCmd = &cobra.Command{
 	Use:   "middleware-generator",
 	Short: "Generates logging middleware for golang interface",
 	Long: `This golang generator can be used to generate a logging
-middleware with the zerolog logging library for an provided interface.
+middleware for an provided interface. The logging library is selected
+with --logger and defaults to zerolog. With --kind metrics a Prometheus
+metrics middleware, with --kind tracing an OpenTelemetry tracing
+middleware, with --kind retry a retry middleware, with --kind
+circuitbreaker a circuit breaker middleware and with --kind timeout a
+timeout middleware is generated instead.
 
 Either use it directly as binary or add it as comment for go:generate --> see examples
 
 For detected bugs please contact: marco-engstler@gmx.de`,
 	Example: `middleware-generator -i "io.Reader" -w "pkg.structname" -o "logging-middleware.go"
 middleware-generator -i "github.com/hanofzelbri/middleware-generator/interfaces.CompositeParamsInterface" -o "logging-middleware.go"
+middleware-generator -i "github.com/hanofzelbri/middleware-generator/interfaces.*" --match "Params" -w "middleware.wrapper" --split -o "middleware"
 
-//go:generate middleware-generator -i "github.com/hanofzelbri/middleware-generator/interfaces.CompositeParamsInterface" -o "logging-middleware.go"`,
+//go:generate middleware-generator -i "github.com/hanofzelbri/middleware-generator/interfaces.CompositeParamsInterface" -o "logging-middleware.go"
+//go:generate middleware-generator`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		i, err := interfaces.BuildInterface(options)
+		options.Check = options.Check || options.Diff
+		if len(options.Queries) == 0 {
+			var err error
+			if options, err = interfaces.GoGenerateOptions(options, os.Getenv); err != nil {
+				return err
+			}
+		}
+		cmd.SilenceUsage = true
+
+		inters, err := interfaces.BuildInterfaces(options)
 		if err != nil {
 			return err
 		}
 
-		template, err := interfaces.InterfaceWrapperTemplate(i)
+		return generate(options, inters)
+	},
+}
+
+// generate renders the middlewares of inters and writes them as configured by options
+func generate(options interfaces.Options, inters []*interfaces.Interface) error {
+	files := make([][]byte, 0, len(inters))
+	for _, i := range inters {
+		template, err := interfaces.Render(i, options)
 		if err != nil {
 			return fmt.Errorf("%v\n\nerr: %v", string(template), err)
 		}
 
-		f := os.Stdout
-		if options.Output != "" {
-			f, err = os.OpenFile(options.Output, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0755)
-			if err != nil {
+		files = append(files, template)
+	}
+
+	if options.Split {
+		dir := options.Output
+		if dir == "" {
+			dir = "."
+		}
+		if !options.Check {
+			if err := os.MkdirAll(dir, 0755); err != nil {
 				return err
 			}
 		}
 
-		_, err = f.Write(template)
-		if err != nil {
+		outputs := make([]string, 0, len(inters))
+		contents := map[string][]byte{}
+		for idx, i := range inters {
+			output := filepath.Join(dir, interfaces.FileName(i))
+			outputs = append(outputs, output)
+			contents[output] = files[idx]
+		}
+		if err := typeCheck(options, contents); err != nil {
+			return err
+		}
+
+		stale := []string{}
+		for _, output := range outputs {
+			if err := writeOutput(options, output, contents[output]); err == errStale {
+				stale = append(stale, output)
+			} else if err != nil {
+				return err
+			}
+		}
+
+		if len(stale) > 0 {
+			return fmt.Errorf("%v out of date", strings.Join(stale, ", "))
+		}
+		return nil
+	}
+
+	template, err := interfaces.CombineFiles(files, options.LocalPrefix)
+	if err != nil {
+		return fmt.Errorf("%v\n\nerr: %v", string(template), err)
+	}
+
+	if options.Output != "" {
+		if err := typeCheck(options, map[string][]byte{options.Output: template}); err != nil {
 			return err
 		}
+	}
 
-		err = f.Close()
+	if err := writeOutput(options, options.Output, template); err == errStale {
+		return fmt.Errorf("%v out of date", options.Output)
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+// typeCheck type-checks the files to be written with their packages, so a
+// middleware which doesn't compile never replaces the previous output
+func typeCheck(options interfaces.Options, files map[string][]byte) error {
+	if options.Check || options.NoTypeCheck {
+		return nil
+	}
+
+	return interfaces.TypeCheck(options, files)
+}
+
+var errStale = errors.New("out of date")
+
+// writeOutput writes content to output or stdout. In check mode output is
+// compared with content instead and errStale is returned if they differ.
+func writeOutput(options interfaces.Options, output string, content []byte) error {
+	if options.Check {
+		return checkOutput(options, output, content)
+	}
+
+	f := os.Stdout
+	if output != "" {
+		var err error
+		f, err = os.OpenFile(output, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0755)
 		if err != nil {
 			return err
 		}
+	}
 
-		fmt.Printf("Successfully wrote middleware to %v\n", options.Output)
+	_, err := f.Write(content)
+	if err != nil {
+		return err
+	}
+
+	err = f.Close()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Successfully wrote middleware to %v\n", output)
+	return nil
+}
+
+func checkOutput(options interfaces.Options, output string, content []byte) error {
+	if output == "" {
+		return fmt.Errorf("--check requires --output")
+	}
+
+	diff, err := interfaces.Diff(output, content)
+	if err != nil {
+		return err
+	}
+	if diff == "" {
 		return nil
-	},
+	}
+
+	if options.Diff {
+		fmt.Print(diff)
+	}
+	return errStale
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -71,12 +195,33 @@ func Execute() {
 }
 
 func init() {
-	rootCmd.PersistentFlags().StringVarP(&options.Query, "interface", "i", "", "Interface definition to generate logging middleware for.")
-	rootCmd.MarkPersistentFlagRequired("interface")
+	rootCmd.PersistentFlags().StringArrayVarP(&options.Queries, "interface", "i", nil, "Interface definition to generate logging middleware for. Can be repeated, path/to/package.* selects all interfaces of a package, path/to/package.type[typeargs] an instantiation of a generic interface. Inferred if run by go generate")
+	rootCmd.PersistentFlags().StringVar(&options.Match, "match", "", "Regular expression interface names selected by path/to/package.* have to match")
+	rootCmd.PersistentFlags().BoolVar(&options.Check, "check", false, "Don't write the middleware but fail if --output is out of date")
+	rootCmd.PersistentFlags().BoolVar(&options.Diff, "diff", false, "Like --check but additionally print a unified diff of out of date files")
+	rootCmd.PersistentFlags().BoolVar(&options.NoTypeCheck, "noTypeCheck", false, "Write the middleware without type-checking it together with its package first")
+	rootCmd.PersistentFlags().BoolVar(&options.Split, "split", false, "Write every interface to its own file in the --output directory instead of one combined file")
 
 	rootCmd.PersistentFlags().StringVarP(&options.Output, "output", "o", "", "Output file. If empty StdOut is used")
+	rootCmd.PersistentFlags().StringVar(&options.LocalPrefix, "localPrefix", "", "Comma-separated import path prefixes grouped after third-party imports like goimports -local")
 	rootCmd.PersistentFlags().StringVarP(&options.Wrapper, "wrapper", "w", "", "Wrapper definition for implementation of middleware interface.")
 	rootCmd.PersistentFlags().StringVarP(&options.MiddlewareFunctionName, "middlewareFunctionName", "f", "WithMiddleware", "Function name for middleware")
 	rootCmd.PersistentFlags().StringVarP(&options.EmptyFunctionParamNamePrefix, "emptyFunctionParamNamePrefix", "p", "param", "If there is no function parameter name provided this prefix will be used")
 	rootCmd.PersistentFlags().StringVarP(&options.EmptyFunctionReturnParamNamePrefix, "emptyFunctionReturnParamNamePrefix", "r", "ret", "If there is no function parameter return name provided this prefix will be used")
+	rootCmd.PersistentFlags().StringVar(&options.Kind, "kind", interfaces.MiddlewareLogging, fmt.Sprintf("Kind of generated middleware. One of: %v", strings.Join(interfaces.MiddlewareKinds(), ", ")))
+	rootCmd.PersistentFlags().StringVar(&options.Logger, "logger", interfaces.LoggerZerolog, fmt.Sprintf("Logging library used by the middleware. One of: %v", strings.Join(interfaces.Loggers(), ", ")))
+	rootCmd.PersistentFlags().StringVar(&options.Level, "level", interfaces.LevelInfo, fmt.Sprintf("Log level for methods without error result. One of: %v", strings.Join(interfaces.Levels(), ", ")))
+	rootCmd.PersistentFlags().StringVar(&options.SuccessLevel, "successLevel", interfaces.LevelInfo, "Log level for methods returning a nil error")
+	rootCmd.PersistentFlags().StringVar(&options.ErrorLevel, "errorLevel", interfaces.LevelError, "Log level for methods returning a non-nil error")
+	rootCmd.PersistentFlags().StringSliceVar(&options.Redact, "redact", nil, "Parameter names or types which are redacted in the log output")
+	rootCmd.PersistentFlags().StringVar(&options.RedactPattern, "redactPattern", "", fmt.Sprintf("Parameters with names matching this regular expression are redacted. If empty %q is used", interfaces.DefaultRedactPattern))
+	rootCmd.PersistentFlags().BoolVar(&options.NoRedactPattern, "noRedactPattern", false, "Don't redact parameters by the name pattern, only the listed and annotated ones")
+	rootCmd.PersistentFlags().StringVar(&options.RedactMode, "redactMode", "", fmt.Sprintf("Additionally log %q or %q of redacted values. If empty only a placeholder is logged", interfaces.RedactModeLength, interfaces.RedactModeHash))
+	rootCmd.PersistentFlags().StringVar(&options.Timeout, "timeout", "", fmt.Sprintf("Default timeout of methods of the timeout middleware. If empty %v is used", interfaces.DefaultTimeout))
+	rootCmd.PersistentFlags().StringToStringVar(&options.Timeouts, "timeouts", nil, "Timeouts of single methods of the timeout middleware, e.g. Get=2s,List=30s")
+	rootCmd.PersistentFlags().StringArrayVarP(&options.Templates, "template", "t", nil, "Template file used instead of the built-in template. Can be repeated, the first file is executed")
+	rootCmd.PersistentFlags().StringArrayVar(&options.TemplateDirs, "templateDir", nil, "Directory to search for template files. Can be repeated")
+	rootCmd.PersistentFlags().StringSliceVar(&options.Tags, "tags", nil, "Build tags used while loading the interface package")
+	rootCmd.PersistentFlags().StringVar(&options.GOOS, "goos", "", "GOOS used while loading the interface package. If empty the environment is used")
+	rootCmd.PersistentFlags().StringVar(&options.GOARCH, "goarch", "", "GOARCH used while loading the interface package. If empty the environment is used")
 }
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/hanofzelbri/middleware-generator/interfaces"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	projectFile string
+)
+
+// runCmd represents the run command
+var runCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Generates all middlewares of a project config file",
+	Long: `Generates all middlewares described by the jobs of a YAML or JSON
+project config file. Every job accepts the fields of the root command
+flags, unset fields default to the defaults section of the file and then
+to the flags. Jobs share a single package load.`,
+	Example: `middleware-generator run
+middleware-generator run -c "config/middleware.json" --tags integration`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		options.Check = options.Check || options.Diff
+		jobs, err := interfaces.ReadProject(projectFile, options)
+		if err != nil {
+			return err
+		}
+
+		jobOptions := make([]interfaces.Options, 0, len(jobs))
+		for _, job := range jobs {
+			jobOptions = append(jobOptions, job.Options)
+		}
+
+		return generateJobs(jobs, interfaces.BuildJobs(jobOptions))
+	},
+}
+
+// generateJobs generates the middlewares of all successfully built jobs and
+// reports the result of every job
+func generateJobs(jobs []interfaces.Job, results []interfaces.JobResult) error {
+	failed := 0
+	for i, result := range results {
+		err := result.Err
+		if err == nil {
+			err = generate(jobs[i].Options, result.Interfaces)
+		}
+
+		if err != nil {
+			failed++
+			fmt.Printf("FAIL %v: %v\n", jobName(jobs[i]), err)
+			continue
+		}
+		fmt.Printf("ok   %v\n", jobName(jobs[i]))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d jobs failed", failed, len(jobs))
+	}
+	return nil
+}
+
+func jobName(job interfaces.Job) string {
+	switch {
+	case job.Name != "":
+		return job.Name
+	case job.Output != "":
+		return job.Output
+	case len(job.Queries) > 0:
+		return job.Queries[0]
+	}
+
+	return job.Query
+}
+
+func init() {
+	rootCmd.AddCommand(runCmd)
+
+	runCmd.Flags().StringVarP(&projectFile, "config", "c", interfaces.DefaultProjectFile, "Project config file describing the jobs")
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/hanofzelbri/middleware-generator/interfaces"
+
+	"github.com/spf13/cobra"
+)
+
+// scanCmd represents the scan command
+var scanCmd = &cobra.Command{
+	Use:   "scan [packages]",
+	Short: "Generates middlewares for all interfaces annotated with a generate marker",
+	Long: `Scans all packages of the current module, or the passed package
+patterns, for interfaces annotated with a generate marker in their doc
+comment and generates all requested middlewares in one run:
+
+  //middleware:generate logging output=logging_gen.go
+  type Store interface { ... }
+
+The optional first argument is the middleware kind, all others are key=value
+fields like in a project config file. The middleware is generated into the
+package of the interface, by default into <interface_name>_middleware.go.`,
+	Example: `middleware-generator scan
+middleware-generator scan ./storage/... --check`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		options.Check = options.Check || options.Diff
+		jobs, results, err := interfaces.Scan(options, args...)
+		if err != nil {
+			return err
+		}
+
+		if len(jobs) == 0 {
+			fmt.Println("No generate markers found")
+			return nil
+		}
+
+		return generateJobs(jobs, results)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(scanCmd)
+}
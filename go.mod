@@ -1,10 +1,20 @@
 module github.com/hanofzelbri/middleware-generator
 
-go 1.14
+go 1.22.0
 
 require (
 	github.com/google/uuid v1.1.1
+	github.com/pmezard/go-difflib v1.0.0
 	github.com/spf13/cobra v1.0.0
 	github.com/stretchr/testify v1.2.2
-	golang.org/x/tools v0.0.0-20200623185156-456ad74e1464
+	golang.org/x/tools v0.30.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/inconshreveable/mousetrap v1.0.0 // indirect
+	github.com/spf13/pflag v1.0.3 // indirect
+	golang.org/x/mod v0.23.0 // indirect
+	golang.org/x/sync v0.11.0 // indirect
 )
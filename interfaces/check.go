@@ -0,0 +1,30 @@
+package interfaces
+
+import (
+	"bytes"
+	"os"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// Diff returns a unified diff from the file at path to the generated content.
+// The diff is empty if the file is up to date. A missing file is treated as
+// empty.
+func Diff(path string, content []byte) (string, error) {
+	current, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+
+	if bytes.Equal(current, content) {
+		return "", nil
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(current)),
+		B:        difflib.SplitLines(string(content)),
+		FromFile: path,
+		ToFile:   path + " (generated)",
+		Context:  3,
+	})
+}
@@ -0,0 +1,57 @@
+package interfaces
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  *string
+		content  string
+		contains []string
+	}{
+		{
+			name:    "up to date",
+			current: stringPtr("package a\n\nfunc A() {}\n"),
+			content: "package a\n\nfunc A() {}\n",
+		},
+		{
+			name:     "out of date",
+			current:  stringPtr("package a\n\nfunc A() {}\n"),
+			content:  "package a\n\nfunc B() {}\n",
+			contains: []string{"--- ", "+++ ", "(generated)", "-func A() {}", "+func B() {}"},
+		},
+		{
+			name:     "missing file",
+			content:  "package a\n",
+			contains: []string{"+package a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "middleware.go")
+			if tt.current != nil {
+				assert.NoError(t, os.WriteFile(path, []byte(*tt.current), 0644))
+			}
+
+			got, err := Diff(path, []byte(tt.content))
+			assert.NoError(t, err)
+
+			if len(tt.contains) == 0 {
+				assert.Empty(t, got)
+			}
+			for _, c := range tt.contains {
+				assert.Contains(t, got, c)
+			}
+		})
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,197 @@
+package interfaces
+
+// circuitBreakerTmpl is the template of the circuit breaker middleware. Calls
+// of methods whose last result is an error are counted as failures if they
+// return a non-nil error or panic. After the configured number of consecutive
+// failures the breaker opens and calls fail with ErrCircuitOpen without
+// calling the wrapped implementation. After the cool-down a single trial call
+// is let through, which closes the breaker on success and opens it again on
+// failure. Every state change starts a new generation of the breaker, results
+// of calls which passed it in an earlier generation are ignored. The exported
+// error type is prefixed with the middleware function
+// name like the options, so several middlewares fit into one package.
+var circuitBreakerTmpl = `// Code generated by github.com/hanofzelbri/middleware-generato; DO NOT EDIT
+
+package {{.WrapperPackageName}}
+{{template "imports"}}
+
+{{if .Comment}}{{.Comment}}{{end -}}
+type {{.WrapperStructName}}{{.TypeParamList}} struct {
+    {{wrapperField}} {{.TypeName}}
+    {{optionsField}} {{.WrapperStructName}}Options
+    {{field "breakers"}} map[string]*{{.WrapperStructName}}Breaker
+}
+
+// {{.MiddleWareFunctionName}}ErrCircuitOpen is returned without calling the wrapped implementation
+// while the circuit breaker of a method is open
+type {{.MiddleWareFunctionName}}ErrCircuitOpen struct {
+    Interface string
+    Method    string
+}
+
+func (e {{.MiddleWareFunctionName}}ErrCircuitOpen) Error() string {
+    return "circuit breaker of " + e.Interface + "." + e.Method + " is open"
+}
+
+type {{.WrapperStructName}}Options struct {
+    threshold     int
+    coolDown      time.Duration
+    perInterface  bool
+    onStateChange func(method string, from string, to string)
+}
+
+// {{.MiddleWareFunctionName}}Option configures the middleware created by {{.MiddleWareFunctionName}}
+type {{.MiddleWareFunctionName}}Option func(*{{.WrapperStructName}}Options)
+
+// {{.MiddleWareFunctionName}}Threshold sets the number of consecutive failures opening the breaker, defaults to 5
+func {{.MiddleWareFunctionName}}Threshold(threshold int) {{.MiddleWareFunctionName}}Option {
+    return func(o *{{.WrapperStructName}}Options) {
+        o.threshold = threshold
+    }
+}
+
+// {{.MiddleWareFunctionName}}CoolDown sets the time after which an open breaker lets a trial call through, defaults to 30s
+func {{.MiddleWareFunctionName}}CoolDown(coolDown time.Duration) {{.MiddleWareFunctionName}}Option {
+    return func(o *{{.WrapperStructName}}Options) {
+        o.coolDown = coolDown
+    }
+}
+
+// {{.MiddleWareFunctionName}}PerInterface shares a single breaker between all methods instead of one breaker per method
+func {{.MiddleWareFunctionName}}PerInterface() {{.MiddleWareFunctionName}}Option {
+    return func(o *{{.WrapperStructName}}Options) {
+        o.perInterface = true
+    }
+}
+
+// {{.MiddleWareFunctionName}}OnStateChange sets a callback which is called with the method of the call
+// causing a transition between the states "closed", "open" and "half-open"
+func {{.MiddleWareFunctionName}}OnStateChange(onStateChange func(method string, from string, to string)) {{.MiddleWareFunctionName}}Option {
+    return func(o *{{.WrapperStructName}}Options) {
+        o.onStateChange = onStateChange
+    }
+}
+
+// {{.MiddleWareFunctionName}} adds a circuit breaker for interface {{.Name}}
+func {{.MiddleWareFunctionName}}{{.TypeParamList}}(wrapper {{.TypeName}}, opts ...{{.MiddleWareFunctionName}}Option) {{.TypeName}} {
+    options := {{.WrapperStructName}}Options{
+        threshold: 5,
+        coolDown:  30 * time.Second,
+    }
+    for _, opt := range opts {
+        opt(&options)
+    }
+
+    shared := &{{.WrapperStructName}}Breaker{state: "closed"}
+    breakers := map[string]*{{.WrapperStructName}}Breaker{}
+    for _, method := range []string{ {{- range $i, $f := .Functions}}{{if .ErrorResult}}"{{.Name}}", {{end}}{{end -}} } {
+        breakers[method] = shared
+        if !options.perInterface {
+            breakers[method] = &{{.WrapperStructName}}Breaker{state: "closed"}
+        }
+    }
+
+    return &{{.WrapperStructName}}{{.TypeArgList}}{
+        {{wrapperField}}: wrapper,
+        {{optionsField}}: options,
+        {{field "breakers"}}: breakers,
+    }
+}
+
+func (o *{{.WrapperStructName}}Options) notify(method string, from string, to string) {
+    if from != to && o.onStateChange != nil {
+        o.onStateChange(method, from, to)
+    }
+}
+
+// {{.WrapperStructName}}Breaker tracks the consecutive failures of a method or of all methods
+type {{.WrapperStructName}}Breaker struct {
+    mu         sync.Mutex
+    state      string
+    generation uint64
+    failures   int
+    openedAt   time.Time
+    trial      bool
+}
+
+// allow reports whether a call of method may pass the breaker and returns the
+// generation to pass to done. An open breaker becomes half-open after the
+// cool-down and lets a single trial call through.
+func (b *{{.WrapperStructName}}Breaker) allow(o *{{.WrapperStructName}}Options, method string) (uint64, bool) {
+    b.mu.Lock()
+    from := b.state
+    if b.state == "open" && time.Since(b.openedAt) >= o.coolDown {
+        b.transition("half-open")
+    }
+    ok := b.state == "closed" || (b.state == "half-open" && !b.trial)
+    if ok && b.state == "half-open" {
+        b.trial = true
+    }
+    to, generation := b.state, b.generation
+    b.mu.Unlock()
+
+    o.notify(method, from, to)
+    return generation, ok
+}
+
+// done records whether a call of method which passed the breaker in
+// generation failed. Results of calls which passed before the last state
+// change are ignored, so a half-open breaker is only closed or opened by its
+// trial call.
+func (b *{{.WrapperStructName}}Breaker) done(o *{{.WrapperStructName}}Options, method string, generation uint64, failed bool) {
+    b.mu.Lock()
+    if generation != b.generation {
+        b.mu.Unlock()
+        return
+    }
+    from := b.state
+    if b.state == "half-open" {
+        b.trial = false
+    }
+    if !failed {
+        b.failures = 0
+        b.transition("closed")
+    } else {
+        b.failures++
+        if b.state == "half-open" || b.failures >= o.threshold {
+            b.transition("open")
+            b.openedAt = time.Now()
+        }
+    }
+    to := b.state
+    b.mu.Unlock()
+
+    o.notify(method, from, to)
+}
+
+// transition changes the state and starts a new generation if it differs
+func (b *{{.WrapperStructName}}Breaker) transition(state string) {
+    if b.state != state {
+        b.state = state
+        b.generation++
+    }
+}
+
+{{range $f := .Functions}}
+{{if .Comment}}{{.Comment}}{{end -}}
+func (l *{{$.WrapperStructName}}{{$.TypeArgList}}) {{.Name}}({{range .Params}}{{.Name}} {{.Type.Name}}, {{end}}) ({{range .Res}}{{.Name}} {{.Type.Name}}, {{end}}) {
+    {{- with .ErrorResult}}
+    breaker := l.{{field "breakers"}}["{{$f.Name}}"]
+    generation, ok := breaker.allow(&l.{{optionsField}}, "{{$f.Name}}")
+    if !ok {
+        {{.Name}} = {{$.MiddleWareFunctionName}}ErrCircuitOpen{Interface: "{{$.ShortName}}", Method: "{{$f.Name}}"}
+        return
+    }
+    defer func() {
+        // A panic is a failure too, it is passed on after it is recorded
+        recovered := recover()
+        breaker.done(&l.{{optionsField}}, "{{$f.Name}}", generation, {{.Name}} != nil || recovered != nil)
+        if recovered != nil {
+            panic(recovered)
+        }
+    }()
+{{end}}
+    {{if .Res}}return{{end}} l.{{wrapperField}}.{{.Name}}({{range $i, $p := .Params}}{{if $i}},{{end}}{{$p.Name}}{{end}}{{if .IsVariadic}}...{{end}})
+}
+{{end}}
+`
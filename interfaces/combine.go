@@ -0,0 +1,102 @@
+package interfaces
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+	"unicode"
+)
+
+// CombineFiles merges rendered middleware files of the same package into a
+// single file. The header of the first file is kept, the imports of all files
+// are merged and grouped like goimports with localPrefix, and the
+// declarations are appended in order.
+func CombineFiles(srcs [][]byte, localPrefix string) ([]byte, error) {
+	if len(srcs) == 0 {
+		return nil, fmt.Errorf("No files to combine")
+	}
+	if len(srcs) == 1 {
+		return srcs[0], nil
+	}
+
+	var header []byte
+	var packageName string
+	imports := []string{}
+	seen := map[string]bool{}
+	bodies := [][]byte{}
+
+	for i, src := range srcs {
+		fset := token.NewFileSet()
+		f, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("Parsing file %d: %v", i+1, err)
+		}
+
+		if i == 0 {
+			packageName = f.Name.Name
+			header = src[:fset.Position(f.Name.End()).Offset]
+		} else if f.Name.Name != packageName {
+			return nil, fmt.Errorf("Cannot combine package %q with package %q", packageName, f.Name.Name)
+		}
+
+		bodyStart := f.Name.End()
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.IMPORT {
+				break
+			}
+
+			for _, spec := range gen.Specs {
+				s := spec.(*ast.ImportSpec)
+				line := s.Path.Value
+				if s.Name != nil {
+					line = s.Name.Name + " " + line
+				}
+				if !seen[line] {
+					seen[line] = true
+					imports = append(imports, line)
+				}
+			}
+			bodyStart = gen.End()
+		}
+
+		bodies = append(bodies, src[fset.Position(bodyStart).Offset:])
+	}
+
+	var buf bytes.Buffer
+	buf.Write(header)
+	buf.WriteString("\n\nimport (\n")
+	for _, line := range imports {
+		fmt.Fprintf(&buf, "\t%v\n", line)
+	}
+	buf.WriteString(")\n")
+	for _, body := range bodies {
+		buf.Write(body)
+	}
+
+	combined, err := formatImports(buf.Bytes(), localPrefix)
+	if err != nil {
+		return buf.Bytes(), err
+	}
+
+	return combined, nil
+}
+
+// FileName returns the snake case file name used for the middleware of inter
+// when every interface is written to its own file
+func FileName(inter *Interface) string {
+	var b strings.Builder
+
+	runes := []rune(inter.ShortName())
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			b.WriteRune('_')
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+
+	return b.String() + "_middleware.go"
+}
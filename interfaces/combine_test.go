@@ -0,0 +1,93 @@
+package interfaces
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCombineFiles(t *testing.T) {
+	files := renderCombineFiles(t, "interfaces.wrapper")
+	if files == nil {
+		return
+	}
+
+	got, err := CombineFiles(files, "")
+	assert.NoError(t, err)
+
+	content := string(got)
+	assert.Equal(t, 1, strings.Count(content, "package interfaces"))
+	assert.Equal(t, 1, strings.Count(content, "import ("))
+	assert.Equal(t, 1, strings.Count(content, `"time"`))
+	assert.Equal(t, 1, strings.Count(content, `"github.com/rs/zerolog"`))
+	assert.Contains(t, content, `"context"`)
+	assert.Contains(t, content, "func WithWrapperCompositeParamsInterface(")
+	assert.Contains(t, content, "func WithWrapperContextParamsInterface(")
+
+	_, err = CombineFiles([][]byte{files[0], []byte("package other\n")}, "")
+	assert.Error(t, err)
+}
+
+func TestCombineFilesLocalPrefix(t *testing.T) {
+	files := renderCombineFiles(t, "tests.wrapper")
+	if files == nil {
+		return
+	}
+
+	got, err := CombineFiles(files, "github.com/hanofzelbri")
+	assert.NoError(t, err)
+
+	content := string(got)
+	assert.Equal(t, 1, strings.Count(content, "package tests"))
+	assert.Equal(t, 1, strings.Count(content, "import ("))
+	assert.Contains(t, content, "\"time\"\n\n\t\"github.com/google/uuid\"")
+	assert.Contains(t, content, "\"github.com/rs/zerolog/log\"\n\n\t\"github.com/hanofzelbri/middleware-generator/interfaces\"\n)")
+}
+
+// renderCombineFiles renders the middlewares of two test interfaces into the package of wrapper
+func renderCombineFiles(t *testing.T, wrapper string) [][]byte {
+	o := Options{
+		Queries: []string{
+			"github.com/hanofzelbri/middleware-generator/interfaces.CompositeParamsInterface",
+			"github.com/hanofzelbri/middleware-generator/interfaces.ContextParamsInterface",
+		},
+		Wrapper:                            wrapper,
+		MiddlewareFunctionName:             "WithWrapper",
+		EmptyFunctionParamNamePrefix:       "param",
+		EmptyFunctionReturnParamNamePrefix: "ret",
+		Logger:                             LoggerZerolog,
+	}
+
+	inters, err := BuildInterfaces(o)
+	if !assert.NoError(t, err) {
+		return nil
+	}
+
+	files := [][]byte{}
+	for _, inter := range inters {
+		file, err := InterfaceWrapperTemplate(inter)
+		if !assert.NoError(t, err) {
+			return nil
+		}
+		files = append(files, file)
+	}
+
+	return files
+}
+
+func TestFileName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "Reader", want: "reader_middleware.go"},
+		{name: "io.ReadWriteCloser", want: "read_write_closer_middleware.go"},
+		{name: "HTTPClient", want: "http_client_middleware.go"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, FileName(&Interface{Name: tt.name}))
+		})
+	}
+}
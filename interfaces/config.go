@@ -1,25 +1,146 @@
 package interfaces
 
 import (
+	"fmt"
+	"go/ast"
 	"go/parser"
+	"go/token"
+	"os"
 	"path/filepath"
 	"strings"
 	"unicode"
 
-	"golang.org/x/tools/go/loader"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/packages"
 )
 
-func loadProgram(packageName string) (*loader.Program, error) {
-	cfg := &loader.Config{
-		AllowErrors:         true,
-		ImportPkgs:          map[string]bool{packageName: true},
-		TypeCheckFuncBodies: func(string) bool { return false },
-		ParserMode:          parser.ParseComments,
+const loadMode = packages.NeedName |
+	packages.NeedFiles |
+	packages.NeedImports |
+	packages.NeedDeps |
+	packages.NeedTypes |
+	packages.NeedSyntax |
+	packages.NeedTypesInfo |
+	packages.NeedModule
+
+// Program contains all packages loaded for a generator run
+type Program struct {
+	Fset     *token.FileSet
+	packages map[string]*packages.Package
+	roots    []string
+}
+
+func loadProgram(options Options, patterns ...string) (*Program, error) {
+	cfg := &packages.Config{
+		Mode:       loadMode,
+		Fset:       token.NewFileSet(),
+		Tests:      true,
+		BuildFlags: buildFlags(options),
+		Env:        buildEnv(options),
+		ParseFile:  parseFile,
+	}
+
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	program := &Program{
+		Fset:     cfg.Fset,
+		packages: map[string]*packages.Package{},
+	}
+
+	seen := map[string]bool{}
+	for _, pkg := range pkgs {
+		if !seen[pkg.PkgPath] {
+			seen[pkg.PkgPath] = true
+			program.roots = append(program.roots, pkg.PkgPath)
+		}
 	}
 
-	cfg.ImportWithTests(packageName)
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		// Prefer the test variant of a package, it additionally contains
+		// the declarations of its _test.go files.
+		if p, ok := program.packages[pkg.PkgPath]; ok && len(p.Syntax) >= len(pkg.Syntax) {
+			return
+		}
+		program.packages[pkg.PkgPath] = pkg
+	})
+
+	return program, nil
+}
+
+// parseFile parses a source file including its comments. Function bodies are
+// dropped because only declarations are needed to generate middlewares.
+func parseFile(fset *token.FileSet, filename string, src []byte) (*ast.File, error) {
+	f, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if f == nil {
+		return nil, err
+	}
+
+	for _, decl := range f.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok {
+			fn.Body = nil
+		}
+	}
+
+	return f, err
+}
+
+func buildFlags(options Options) []string {
+	if len(options.Tags) == 0 {
+		return nil
+	}
+
+	return []string{fmt.Sprintf("-tags=%v", strings.Join(options.Tags, ","))}
+}
+
+func buildEnv(options Options) []string {
+	env := os.Environ()
+
+	if options.GOOS != "" {
+		env = append(env, "GOOS="+options.GOOS)
+	}
+	if options.GOARCH != "" {
+		env = append(env, "GOARCH="+options.GOARCH)
+	}
+
+	return env
+}
+
+// Package returns the loaded package for import path or nil if it wasn't loaded
+func (p *Program) Package(path string) *packages.Package {
+	return p.packages[path]
+}
+
+// PathEnclosingInterval returns the package and ast path of the innermost
+// nodes enclosing the source interval [start, end)
+func (p *Program) PathEnclosingInterval(start, end token.Pos) (*packages.Package, []ast.Node, bool) {
+	for _, pkg := range p.packages {
+		for _, f := range pkg.Syntax {
+			if f.Pos() == token.NoPos {
+				continue
+			}
+			if !tokenFileContainsPos(p.Fset.File(f.Pos()), start) {
+				continue
+			}
+			if path, exact := astutil.PathEnclosingInterval(f, start, end); path != nil {
+				return pkg, path, exact
+			}
+		}
+	}
+
+	return nil, nil, false
+}
+
+func tokenFileContainsPos(f *token.File, pos token.Pos) bool {
+	if f == nil {
+		return false
+	}
 
-	return cfg.Load()
+	p := int(pos)
+	base := f.Base()
+	return base <= p && p <= base+f.Size()
 }
 
 func wrapperPackageName(wrapper string, packageName string) string {
@@ -0,0 +1,110 @@
+package interfaces
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// GoGenerateOptions completes options without query from the environment
+// variables GOFILE, GOLINE and GOPACKAGE set by go generate. The interface
+// declared below the go:generate directive is used, or the only interface of
+// the file. The wrapper package defaults to GOPACKAGE and the output file
+// to the snake case interface name.
+func GoGenerateOptions(options Options, getenv func(string) string) (Options, error) {
+	file := getenv("GOFILE")
+	if file == "" {
+		return options, fmt.Errorf("--interface (-i) flag is required if not run by go generate")
+	}
+
+	line, err := strconv.Atoi(getenv("GOLINE"))
+	if err != nil {
+		return options, fmt.Errorf("Invalid GOLINE %q: %v", getenv("GOLINE"), err)
+	}
+
+	interfaceName, err := generateInterface(file, line)
+	if err != nil {
+		return options, err
+	}
+
+	pkgs, err := packages.Load(&packages.Config{
+		Mode:       packages.NeedName,
+		BuildFlags: buildFlags(options),
+		Env:        buildEnv(options),
+	}, ".")
+	if err != nil {
+		return options, err
+	}
+	if len(pkgs) == 0 || pkgs[0].PkgPath == "" {
+		return options, fmt.Errorf("Package of %q could not be loaded", file)
+	}
+
+	options.Queries = []string{pkgs[0].PkgPath + "." + interfaceName}
+
+	packageName := getenv("GOPACKAGE")
+	if packageName == "" {
+		packageName = pkgs[0].Name
+	}
+	if options.Wrapper == "" {
+		options.Wrapper = packageName + "." + wrapperStructName("", interfaceName)
+	} else if !strings.Contains(options.Wrapper, ".") {
+		options.Wrapper = packageName + "." + options.Wrapper
+	}
+
+	if options.Output == "" {
+		options.Output = FileName(&Interface{Name: interfaceName})
+	}
+
+	return options, nil
+}
+
+// generateInterface returns the name of the interface declared below the
+// directive in line of file or the only interface of file
+func generateInterface(file string, line int) (string, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, nil, parser.ParseComments)
+	if err != nil {
+		return "", err
+	}
+
+	all := []string{}
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+
+		names := []string{}
+		for _, spec := range gen.Specs {
+			if s, ok := spec.(*ast.TypeSpec); ok {
+				if _, ok := s.Type.(*ast.InterfaceType); ok {
+					names = append(names, s.Name.Name)
+				}
+			}
+		}
+		all = append(all, names...)
+
+		// The directive is part of the doc comment or directly above the declaration
+		start := gen.Pos()
+		if gen.Doc != nil {
+			start = gen.Doc.Pos()
+		}
+		if fset.Position(start).Line-1 <= line && line < fset.Position(gen.Pos()).Line && len(names) == 1 {
+			return names[0], nil
+		}
+	}
+
+	if len(all) == 1 {
+		return all[0], nil
+	}
+	if len(all) == 0 {
+		return "", fmt.Errorf("No interface found in %q", file)
+	}
+
+	return "", fmt.Errorf("%q contains several interfaces (%v), place the go:generate directive directly above one of them or use --interface (-i)", file, strings.Join(all, ", "))
+}
@@ -0,0 +1,114 @@
+package interfaces
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const generateSource = `package store
+
+//go:generate middleware-generator
+// Store persists values.
+type Store interface {
+	Get(key string) (string, error)
+}
+
+type Value struct{}
+
+//go:generate middleware-generator
+
+type Cache interface {
+	Put(key, value string)
+}
+`
+
+func TestGenerateInterface(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		line    int
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "directive in doc comment",
+			source: generateSource,
+			line:   3,
+			want:   "Store",
+		},
+		{
+			name:    "directive not directly above",
+			source:  generateSource,
+			line:    11,
+			wantErr: true,
+		},
+		{
+			name:   "only interface of file",
+			source: "package store\n\n//go:generate middleware-generator\n\n// Store persists values.\ntype Store interface{}\n",
+			line:   3,
+			want:   "Store",
+		},
+		{
+			name:    "no interface",
+			source:  "package store\n\n//go:generate middleware-generator\ntype Value struct{}\n",
+			line:    3,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "store.go")
+			assert.NoError(t, os.WriteFile(file, []byte(tt.source), 0644))
+
+			got, err := generateInterface(file, tt.line)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGoGenerateOptions(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/store\n\ngo 1.22\n"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "store.go"), []byte(generateSource), 0644))
+
+	wd, err := os.Getwd()
+	if !assert.NoError(t, err) || !assert.NoError(t, os.Chdir(dir)) {
+		return
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	env := map[string]string{
+		"GOFILE":    "store.go",
+		"GOLINE":    "3",
+		"GOPACKAGE": "store",
+	}
+	getenv := func(key string) string { return env[key] }
+
+	got, err := GoGenerateOptions(Options{}, getenv)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"example.com/store.Store"}, got.Queries)
+		assert.Equal(t, "store.store", got.Wrapper)
+		assert.Equal(t, "store_middleware.go", got.Output)
+	}
+
+	got, err = GoGenerateOptions(Options{Wrapper: "logging", Output: "logging_gen.go"}, getenv)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "store.logging", got.Wrapper)
+		assert.Equal(t, "logging_gen.go", got.Output)
+	}
+
+	env["GOLINE"] = "1"
+	_, err = GoGenerateOptions(Options{}, getenv)
+	assert.Error(t, err)
+
+	_, err = GoGenerateOptions(Options{}, func(string) string { return "" })
+	assert.Error(t, err)
+}
@@ -0,0 +1,76 @@
+package interfaces
+
+import (
+	"fmt"
+	"go/types"
+)
+
+// bodyIdentifiers are the identifiers besides package names which the
+// templates declare or use in the generated methods
+var bodyIdentifiers = []string{"l", "begin", "logger", "ctxLogger", "event", "level", "logFn", "entry", "logLevel", "ok", "span", "attempt", "breaker", "generation", "timeout", "parent", "cancel", "call", "returned", "recovered"}
+
+// renameParams renames parameters and results of inter which collide with
+// identifiers used in the generated methods: the receiver, the variables of
+// the templates, imported packages, predeclared identifiers and type
+// parameters. A colliding name gets the lowest free numeric suffix, e.g.
+// time2, so the output compiles and is stable between runs. The declared name
+// is kept as OriginalName, values are still logged with it.
+func renameParams(inter *Interface, im *importer) {
+	reserved := map[string]bool{}
+	for _, name := range bodyIdentifiers {
+		reserved[name] = true
+	}
+	for name := range im.names {
+		reserved[name] = true
+	}
+	for _, name := range types.Universe.Names() {
+		reserved[name] = true
+	}
+	for _, p := range inter.TypeParams {
+		reserved[p.Name] = true
+	}
+
+	for fi := range inter.Functions {
+		f := &inter.Functions[fi]
+
+		used := map[string]bool{}
+		for _, params := range [][]Param{f.Params, f.Res} {
+			for _, p := range params {
+				used[p.Name] = true
+			}
+		}
+
+		for _, params := range [][]Param{f.Params, f.Res} {
+			for i, p := range params {
+				if !reserved[p.Name] {
+					continue
+				}
+
+				name := p.Name
+				for n := 2; reserved[name] || used[name]; n++ {
+					name = fmt.Sprintf("%v%d", p.Name, n)
+				}
+				used[name] = true
+				params[i].Name = name
+				params[i].OriginalName = p.Name
+			}
+		}
+	}
+}
+
+// fieldName returns name or, if i has a method with the same name, name
+// with the lowest free numeric suffix. It's used for the fields of the
+// generated wrapper struct, which can't have the name of a method.
+func fieldName(i *Interface, name string) string {
+	methods := map[string]bool{}
+	for _, f := range i.Functions {
+		methods[f.Name] = true
+	}
+
+	field := name
+	for n := 2; methods[field]; n++ {
+		field = fmt.Sprintf("%v%d", name, n)
+	}
+
+	return field
+}
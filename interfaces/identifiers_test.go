@@ -0,0 +1,40 @@
+package interfaces
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenameParams(t *testing.T) {
+	inter := &Interface{
+		TypeParams: []TypeParam{{Name: "K"}},
+		Functions: []Func{
+			{
+				Name:   "Get",
+				Params: []Param{{Name: "time"}, {Name: "time2"}, {Name: "K"}, {Name: "key"}},
+				Res:    []Param{{Name: "l"}, {Name: "err"}},
+			},
+		},
+	}
+
+	renameParams(inter, newImporter("", reservedImports(Options{Logger: LoggerSlog})))
+
+	names := []string{}
+	keys := []string{}
+	for _, params := range [][]Param{inter.Functions[0].Params, inter.Functions[0].Res} {
+		for _, p := range params {
+			names = append(names, p.Name)
+			keys = append(keys, p.Key())
+		}
+	}
+	assert.Equal(t, []string{"time3", "time2", "K2", "key", "l2", "err"}, names)
+	assert.Equal(t, []string{"time", "time2", "K", "key", "l", "err"}, keys)
+}
+
+func TestFieldName(t *testing.T) {
+	inter := &Interface{Functions: []Func{{Name: "options"}, {Name: "options2"}, {Name: "Get"}}}
+
+	assert.Equal(t, "wrapper", fieldName(inter, "wrapper"))
+	assert.Equal(t, "options3", fieldName(inter, "options"))
+}
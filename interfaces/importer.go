@@ -0,0 +1,91 @@
+package interfaces
+
+import (
+	"fmt"
+	"go/types"
+)
+
+// importer allocates unique package names for the imports of a generated
+// file and qualifies types relative to the wrapper package
+type importer struct {
+	self    string
+	names   map[string]string
+	imports map[string]Import
+}
+
+// newImporter returns an importer for a wrapper in the package with path
+// self. The names of reserved imports, which are used by the templates, are
+// never allocated for other packages.
+func newImporter(self string, reserved []Import) *importer {
+	im := &importer{
+		self:    self,
+		names:   map[string]string{},
+		imports: map[string]Import{},
+	}
+
+	for _, i := range reserved {
+		im.names[i.Package] = i.Path
+	}
+
+	return im
+}
+
+// reservedImports returns the imports used by the templates for options
+func reservedImports(options Options) []Import {
+	reserved := []Import{{Package: "time", Path: "time"}, {Package: "fmt", Path: "fmt"}, {Package: "sha256", Path: "crypto/sha256"}}
+	if kind, ok := kindTemplates[options.Kind]; ok {
+		reserved = append(reserved, kind.imports...)
+	} else if backend, err := loggerTemplate(options.Logger); err == nil {
+		reserved = append(reserved, backend.imports...)
+	}
+
+	return reserved
+}
+
+// add returns the import of the package with path, allocating a unique
+// name based on the package name on first use
+func (im *importer) add(path string, name string) Import {
+	if i, ok := im.imports[path]; ok {
+		return i
+	}
+
+	alias := name
+	for n := 2; ; n++ {
+		if p, ok := im.names[alias]; !ok || p == path {
+			break
+		}
+		alias = fmt.Sprintf("%v%d", name, n)
+	}
+
+	i := Import{Package: alias, Path: path}
+	im.names[alias] = path
+	im.imports[path] = i
+
+	return i
+}
+
+// qualifier returns a types.Qualifier which appends the imports it uses to
+// imports. Types of the wrapper package are not qualified.
+func (im *importer) qualifier(imports *[]Import) types.Qualifier {
+	return func(pkg *types.Package) string {
+		if pkg.Path() == im.self {
+			return ""
+		}
+
+		i := im.add(pkg.Path(), pkg.Name())
+		for _, imp := range *imports {
+			if imp.Path == i.Path {
+				return i.Package
+			}
+		}
+		*imports = append(*imports, i)
+
+		return i.Package
+	}
+}
+
+// typeString returns the name of typ relative to the wrapper package and
+// appends the required imports to imports
+func (im *importer) typeString(typ types.Type, imports *[]Import) string {
+	return types.TypeString(typ, im.qualifier(imports))
+}
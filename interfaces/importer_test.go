@@ -0,0 +1,60 @@
+package interfaces
+
+import (
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImporter(t *testing.T) {
+	named := func(path, name, typeName string) types.Type {
+		pkg := types.NewPackage(path, name)
+		return types.NewNamed(types.NewTypeName(0, pkg, typeName, nil), types.Typ[types.Int], nil)
+	}
+
+	im := newImporter("example.com/self", []Import{{Package: "log", Path: "github.com/rs/zerolog/log"}})
+
+	tests := []struct {
+		typ     types.Type
+		want    string
+		imports []Import
+	}{
+		{
+			typ:  named("example.com/self", "self", "Own"),
+			want: "Own",
+		},
+		{
+			typ:     types.NewSlice(named("example.com/a", "x", "A")),
+			want:    "[]x.A",
+			imports: []Import{{Package: "x", Path: "example.com/a"}},
+		},
+		{
+			typ:     types.NewMap(named("example.com/a/b", "b", "B"), named("example.com/a", "x", "A")),
+			want:    "map[b.B]x.A",
+			imports: []Import{{Package: "b", Path: "example.com/a/b"}, {Package: "x", Path: "example.com/a"}},
+		},
+		{
+			typ:     types.NewPointer(named("example.com/other/x", "x", "C")),
+			want:    "*x2.C",
+			imports: []Import{{Package: "x2", Path: "example.com/other/x"}},
+		},
+		{
+			typ:     named("log", "log", "Logger"),
+			want:    "log2.Logger",
+			imports: []Import{{Package: "log2", Path: "log"}},
+		},
+		{
+			typ:     named("github.com/rs/zerolog/log", "log", "Event"),
+			want:    "log.Event",
+			imports: []Import{{Package: "log", Path: "github.com/rs/zerolog/log"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			var imports []Import
+			assert.Equal(t, tt.want, im.typeString(tt.typ, &imports))
+			assert.Equal(t, tt.imports, imports)
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package interfaces
+
+import (
+	"sync"
+
+	"golang.org/x/tools/imports"
+)
+
+// localPrefixMu guards imports.LocalPrefix, which is a package variable
+var localPrefixMu sync.Mutex
+
+// formatImports formats src like goimports: unused imports are removed,
+// missing imports are added and the imports are grouped into standard
+// library, third-party and, if localPrefix is set, local packages.
+// localPrefix is a comma-separated list of import path prefixes.
+func formatImports(src []byte, localPrefix string) ([]byte, error) {
+	localPrefixMu.Lock()
+	defer localPrefixMu.Unlock()
+
+	imports.LocalPrefix = localPrefix
+
+	return imports.Process("", src, &imports.Options{Comments: true, TabIndent: true, TabWidth: 8})
+}
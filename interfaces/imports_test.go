@@ -0,0 +1,40 @@
+package interfaces
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatImports(t *testing.T) {
+	tests := []struct {
+		name        string
+		localPrefix string
+		src         string
+		want        string
+	}{
+		{
+			name: "unused",
+			src:  "package p\n\nimport (\n\"time\"\n\"fmt\"\n)\n\nvar _ = fmt.Sprint()\n",
+			want: "package p\n\nimport (\n\t\"fmt\"\n)\n\nvar _ = fmt.Sprint()\n",
+		},
+		{
+			name: "missing",
+			src:  "package p\n\nvar _ = strings.ToUpper(\"\")\n",
+			want: "package p\n\nimport \"strings\"\n\nvar _ = strings.ToUpper(\"\")\n",
+		},
+		{
+			name:        "groups",
+			localPrefix: "example.com/app",
+			src:         "package p\n\nimport (\n\"example.com/app/model\"\n\"github.com/rs/zerolog\"\n\"time\"\n)\n\nvar _ model.User\nvar _ zerolog.Logger\nvar _ time.Time\n",
+			want:        "package p\n\nimport (\n\t\"time\"\n\n\t\"github.com/rs/zerolog\"\n\n\t\"example.com/app/model\"\n)\n\nvar _ model.User\nvar _ zerolog.Logger\nvar _ time.Time\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formatImports([]byte(tt.src), tt.localPrefix)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
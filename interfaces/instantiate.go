@@ -0,0 +1,140 @@
+package interfaces
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"regexp"
+	"strings"
+)
+
+// qualifiedIdent matches a type name qualified by an import path or package
+// name like example.com/model.User or model.User
+var qualifiedIdent = regexp.MustCompile(`([\w\-.~/]+)\.([A-Za-z_]\w*)`)
+
+// splitTypeArgs splits a type name like Store[string,*model.User] into the
+// name and its type arguments
+func splitTypeArgs(name string) (string, []string, error) {
+	idx := strings.Index(name, "[")
+	if idx == -1 {
+		return name, nil, nil
+	}
+	if !strings.HasSuffix(name, "]") || idx == 0 {
+		return "", nil, fmt.Errorf("Invalid type arguments in %q", name)
+	}
+
+	args := []string{}
+	depth, start := 0, idx+1
+	for i := start; i < len(name)-1; i++ {
+		switch name[i] {
+		case '[', '(', '{':
+			depth++
+		case ']', ')', '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				args = append(args, strings.TrimSpace(name[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	args = append(args, strings.TrimSpace(name[start:len(name)-1]))
+
+	for _, arg := range args {
+		if arg == "" {
+			return "", nil, fmt.Errorf("Invalid type arguments in %q", name)
+		}
+	}
+
+	return name[:idx], args, nil
+}
+
+// typeArgPackages returns the import paths or package names qualifying the
+// types used in args
+func typeArgPackages(args []string) []string {
+	paths := []string{}
+	for _, arg := range args {
+		for _, m := range qualifiedIdent.FindAllStringSubmatch(arg, -1) {
+			paths = append(paths, m[1])
+		}
+	}
+
+	return paths
+}
+
+// instantiate instantiates the generic type of obj with the type arguments
+// args. Qualifiers of the type arguments are either import paths or names of
+// packages imported by the package of obj.
+func instantiate(program *Program, obj types.Object, args []string) (types.Type, []types.Type, error) {
+	named, ok := obj.Type().(*types.Named)
+	if !ok || named.TypeParams().Len() == 0 {
+		return nil, nil, fmt.Errorf("Interface %q in package %q is not generic and can't be instantiated", obj.Name(), obj.Pkg().Path())
+	}
+
+	scope := types.NewPackage("typeargs", "typeargs")
+	aliases := map[string]string{}
+
+	targs := make([]types.Type, 0, len(args))
+	for _, arg := range args {
+		var err error
+		expr := qualifiedIdent.ReplaceAllStringFunc(arg, func(s string) string {
+			m := qualifiedIdent.FindStringSubmatch(s)
+
+			alias, ok := aliases[m[1]]
+			if !ok {
+				pkg := typeArgPackage(program, obj.Pkg(), m[1])
+				if pkg == nil {
+					err = fmt.Errorf("Package %q of type argument %q could not be found", m[1], arg)
+					return s
+				}
+
+				alias = fmt.Sprintf("p%d", len(aliases))
+				aliases[m[1]] = alias
+				scope.Scope().Insert(types.NewPkgName(token.NoPos, scope, alias, pkg))
+			}
+
+			return alias + "." + m[2]
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		tv, err := types.Eval(program.Fset, scope, token.NoPos, expr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Invalid type argument %q: %v", arg, err)
+		}
+		if !tv.IsType() {
+			return nil, nil, fmt.Errorf("Type argument %q is not a type", arg)
+		}
+		targs = append(targs, tv.Type)
+	}
+
+	typ, err := types.Instantiate(types.NewContext(), named, targs, true)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Interface %q in package %q can't be instantiated: %v", obj.Name(), obj.Pkg().Path(), err)
+	}
+
+	return typ, targs, nil
+}
+
+// typeArgPackage returns the package with the import path or name qualifier.
+// pkg and its imports are preferred over other loaded packages.
+func typeArgPackage(program *Program, pkg *types.Package, qualifier string) *types.Package {
+	candidates := append([]*types.Package{pkg}, pkg.Imports()...)
+	for _, p := range candidates {
+		if p.Path() == qualifier {
+			return p
+		}
+	}
+	for _, p := range candidates {
+		if p.Name() == qualifier {
+			return p
+		}
+	}
+
+	if p := program.Package(qualifier); p != nil && p.Types != nil && len(p.Errors) == 0 {
+		return p.Types
+	}
+
+	return nil
+}
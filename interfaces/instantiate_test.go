@@ -0,0 +1,70 @@
+package interfaces
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitTypeArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		wantName string
+		wantArgs []string
+		wantErr  bool
+	}{
+		{name: "Store", wantName: "Store"},
+		{name: "Store[string]", wantName: "Store", wantArgs: []string{"string"}},
+		{name: "Store[string,*model.User]", wantName: "Store", wantArgs: []string{"string", "*model.User"}},
+		{name: "Store[map[string]int, func(a, b int) error, struct{ A, B int }]", wantName: "Store", wantArgs: []string{"map[string]int", "func(a, b int) error", "struct{ A, B int }"}},
+		{name: "Store[string", wantErr: true},
+		{name: "Store[]", wantErr: true},
+		{name: "Store[string,]", wantErr: true},
+		{name: "[string]", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, args, err := splitTypeArgs(tt.name)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantName, name)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestTypeArgPackages(t *testing.T) {
+	got := typeArgPackages([]string{"string", "*model.User", "map[string]example.com/app/model.User", "[]time.Duration"})
+	assert.Equal(t, []string{"model", "example.com/app/model", "time"}, got)
+}
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		query         string
+		packageName   string
+		interfaceName string
+		wantErr       bool
+	}{
+		{query: "io.Reader", packageName: "io", interfaceName: "Reader"},
+		{query: "example.com/cache.Store[string,*example.com/model.User]", packageName: "example.com/cache", interfaceName: "Store[string,*example.com/model.User]"},
+		{query: "example.com/cache.*", packageName: "example.com/cache", interfaceName: "*"},
+		{query: "Reader", wantErr: true},
+		{query: "io.", wantErr: true},
+		{query: "Store[model.User]", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			packageName, interfaceName, err := parseQuery(tt.query)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.packageName, packageName)
+			assert.Equal(t, tt.interfaceName, interfaceName)
+		})
+	}
+}
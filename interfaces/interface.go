@@ -5,29 +5,343 @@ import (
 	"go/ast"
 	"go/token"
 	"go/types"
+	"regexp"
 	"sort"
 	"strings"
 )
 
 // BuildInterface creates an Interface object for provided options
 func BuildInterface(options Options) (*Interface, error) {
-	config, err := setupConfig(options)
+	redactor, timeouts, err := validateOptions(options)
 	if err != nil {
 		return nil, err
 	}
 
-	iface, ok := config.Object.Type().Underlying().(*types.Interface)
+	packageName, interfaceName, err := parseQuery(options.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	_, args, err := splitTypeArgs(interfaceName)
+	if err != nil {
+		return nil, err
+	}
+
+	program, err := loadProgram(options, append([]string{packageName}, typeArgPackages(args)...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := setupConfig(program, options, packageName, interfaceName)
+	if err != nil {
+		return nil, err
+	}
+	config.redactor = redactor
+	config.timeouts = timeouts
+
+	inter, err := buildInterface(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return inter, checkTimeouts(options, []*Interface{inter})
+}
+
+// BuildInterfaces creates Interface objects for all queries of provided
+// options. All packages are loaded at once. A query like path/to/package.*
+// selects all interfaces of the package with names matching options.Match.
+// If more than one interface is selected, wrapper struct names are derived
+// from the interface names and the interface name is appended to the
+// middleware function name.
+func BuildInterfaces(options Options) ([]*Interface, error) {
+	results := BuildJobs([]Options{options})
+
+	return results[0].Interfaces, results[0].Err
+}
+
+// JobResult contains the interfaces built for a job or the reason it failed
+type JobResult struct {
+	Interfaces []*Interface
+	Err        error
+}
+
+// BuildJobs creates the Interface objects of several jobs like
+// BuildInterfaces. Jobs using the same build tags, GOOS and GOARCH share a
+// single package load. A failing job doesn't stop the other jobs.
+func BuildJobs(jobs []Options) []JobResult {
+	return buildJobs(jobs, loadProgram)
+}
+
+// buildJobs builds jobs with the packages returned by load
+func buildJobs(jobs []Options, load func(Options, ...string) (*Program, error)) []JobResult {
+	results := make([]JobResult, len(jobs))
+
+	groups := map[string][]int{}
+	keys := []string{}
+	requests := make([]*request, len(jobs))
+	for i, options := range jobs {
+		r, err := newRequest(options)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		requests[i] = r
+
+		key := loadKey(options)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], i)
+	}
+
+	for _, key := range keys {
+		group := groups[key]
+
+		packageNames := []string{}
+		for _, i := range group {
+			packageNames = append(packageNames, requests[i].packageNames...)
+			packageNames = append(packageNames, requests[i].typeArgPackages...)
+		}
+
+		program, err := load(jobs[group[0]], packageNames...)
+		for _, i := range group {
+			if err != nil {
+				results[i].Err = err
+				continue
+			}
+
+			results[i].Interfaces, results[i].Err = requests[i].build(program)
+		}
+	}
+
+	return results
+}
+
+// request contains the parsed queries of a job
+type request struct {
+	options         Options
+	redactor        *redactor
+	timeouts        *timeouts
+	packageNames    []string
+	interfaceNames  []string
+	typeArgPackages []string
+}
+
+func newRequest(options Options) (*request, error) {
+	redactor, timeouts, err := validateOptions(options)
+	if err != nil {
+		return nil, err
+	}
+
+	queries := options.Queries
+	if len(queries) == 0 {
+		queries = []string{options.Query}
+	}
+
+	r := &request{options: options, redactor: redactor, timeouts: timeouts}
+	for _, query := range queries {
+		packageName, interfaceName, err := parseQuery(query)
+		if err != nil {
+			return nil, err
+		}
+
+		_, args, err := splitTypeArgs(interfaceName)
+		if err != nil {
+			return nil, err
+		}
+
+		r.packageNames = append(r.packageNames, packageName)
+		r.interfaceNames = append(r.interfaceNames, interfaceName)
+		r.typeArgPackages = append(r.typeArgPackages, typeArgPackages(args)...)
+	}
+
+	return r, nil
+}
+
+func (r *request) build(program *Program) ([]*Interface, error) {
+	var err error
+
+	configs := []*Config{}
+	for i, packageName := range r.packageNames {
+		names := []string{r.interfaceNames[i]}
+		if names[0] == "*" {
+			names, err = packageInterfaces(program, r.options, packageName)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for _, interfaceName := range names {
+			config, err := setupConfig(program, r.options, packageName, interfaceName)
+			if err != nil {
+				return nil, err
+			}
+			config.redactor = r.redactor
+			config.timeouts = r.timeouts
+
+			configs = append(configs, config)
+		}
+	}
+
+	if len(configs) > 1 {
+		for _, config := range configs {
+			config.WrapperStructName = wrapperStructName("", config.InterfaceName)
+			config.Options.MiddlewareFunctionName += config.InterfaceName
+		}
+	}
+
+	inters := make([]*Interface, 0, len(configs))
+	for _, config := range configs {
+		inter, err := buildInterface(config)
+		if err != nil {
+			return nil, err
+		}
+
+		inters = append(inters, inter)
+	}
+
+	if err := checkTimeouts(r.options, inters); err != nil {
+		return nil, err
+	}
+
+	return inters, nil
+}
+
+// loadKey identifies the options which influence loading packages
+func loadKey(options Options) string {
+	return strings.Join([]string{strings.Join(options.Tags, ","), options.GOOS, options.GOARCH}, "|")
+}
+
+// validateOptions returns the redactor and the parsed timeouts of options,
+// which are shared by all interfaces built with them
+func validateOptions(options Options) (*redactor, *timeouts, error) {
+	if err := validateKind(options.Kind); err != nil {
+		return nil, nil, err
+	}
+	// Custom templates are rendered with the definitions of the logger
+	if len(options.Templates) > 0 && options.Kind != "" && options.Kind != MiddlewareLogging {
+		return nil, nil, fmt.Errorf("--template (-t) can't be combined with middleware kind %q", options.Kind)
+	}
+
+	for _, level := range []string{options.Level, options.SuccessLevel, options.ErrorLevel} {
+		if err := validateLevel(level); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	timeouts, err := parseTimeouts(options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	redactor, err := newRedactor(options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return redactor, timeouts, nil
+}
+
+// parseQuery splits query into the package path and the type name, which
+// includes the type arguments of an instantiation like Store[string,int]
+func parseQuery(query string) (string, string, error) {
+	name := query
+	if i := strings.Index(query, "["); i != -1 {
+		name = query[:i]
+	}
+
+	idx := strings.LastIndex(name, ".")
+	if idx == -1 || query[:idx] == "" || query[idx+1:] == "" {
+		return "", "", fmt.Errorf("--interface (-i) flag should be like path/to/package.type or path/to/package.type[typeargs]")
+	}
+
+	return query[:idx], query[idx+1:], nil
+}
+
+// packageInterfaces returns the names of all interfaces in package matching
+// options.Match. Unexported interfaces are only returned if the middleware
+// is generated into the same package.
+func packageInterfaces(program *Program, options Options, packageName string) ([]string, error) {
+	p := program.Package(packageName)
+	if p == nil || p.Types == nil {
+		return nil, fmt.Errorf("Package %q could not be loaded", packageName)
+	}
+
+	var match *regexp.Regexp
+	if options.Match != "" {
+		var err error
+		if match, err = regexp.Compile(options.Match); err != nil {
+			return nil, fmt.Errorf("Invalid match pattern %q: %v", options.Match, err)
+		}
+	}
+
+	samePackage := wrapperPackageName(options.Wrapper, packageName) == p.Types.Name()
+
+	names := []string{}
+	for _, name := range p.Types.Scope().Names() {
+		obj, ok := p.Types.Scope().Lookup(name).(*types.TypeName)
+		if !ok || obj.IsAlias() || (!obj.Exported() && !samePackage) {
+			continue
+		}
+		// Constraints with type sets can't be wrapped
+		if iface, ok := obj.Type().Underlying().(*types.Interface); !ok || !iface.IsMethodSet() {
+			continue
+		}
+		if match != nil && !match.MatchString(name) {
+			continue
+		}
+
+		names = append(names, name)
+	}
+
+	if len(names) == 0 {
+		return nil, fmt.Errorf("No interface found in package %q", packageName)
+	}
+
+	return names, nil
+}
+
+func buildInterface(config *Config) (*Interface, error) {
+	iface, ok := config.Type.Underlying().(*types.Interface)
 	if !ok {
 		return nil, fmt.Errorf("Passed type name %q in package %q is not an interface", config.InterfaceName, config.Package.Path())
 	}
+	if !iface.IsMethodSet() {
+		return nil, fmt.Errorf("Interface %q in package %q is a constraint with a type set and can only be used as type parameter constraint", config.InterfaceName, config.Package.Path())
+	}
+
+	self := ""
+	if config.Package.Name() == config.WrapperPackageName {
+		self = config.Package.Path()
+	}
+	config.importer = newImporter(self, reservedImports(config.Options))
+
+	if self == "" {
+		if err := checkExported(config, iface); err != nil {
+			return nil, err
+		}
+	}
 
 	inter := &Interface{
 		Name:                   config.InterfaceName,
-		Comment:                commentText(config, config.Object.Pos()),
+		Comment:                stripMarkers(commentText(config.Program, config.Object.Pos())),
+		TypeParams:             interfaceTypeParams(config),
+		TypeArgs:               interfaceTypeArgs(config),
 		Functions:              interfaceFunctions(config, iface),
 		WrapperStructName:      config.WrapperStructName,
 		WrapperPackageName:     config.WrapperPackageName,
 		MiddleWareFunctionName: config.Options.MiddlewareFunctionName,
+		Kind:                   config.Options.Kind,
+		Logger:                 config.Options.Logger,
+		Level:                  config.Options.Level,
+		SuccessLevel:           config.Options.SuccessLevel,
+		ErrorLevel:             config.Options.ErrorLevel,
+		RedactMode:             config.Options.RedactMode,
+		LocalPrefix:            config.Options.LocalPrefix,
+		Timeout:                config.timeouts.timeout,
+	}
+	for fi := range inter.Functions {
+		inter.Functions[fi].Timeout = config.timeouts.methods[inter.Functions[fi].Name]
 	}
 
 	fixupInterface(inter, config)
@@ -35,42 +349,56 @@ func BuildInterface(options Options) (*Interface, error) {
 	return inter, nil
 }
 
-func setupConfig(options Options) (*Config, error) {
-	idx := strings.LastIndex(options.Query, ".")
-	if idx == -1 || options.Query[:idx] == "" || options.Query[idx+1:] == "" {
-		return nil, fmt.Errorf("--interface (-i) flag should be like path/to/package.type")
+func setupConfig(program *Program, options Options, packageName string, interfaceName string) (*Config, error) {
+	p := program.Package(packageName)
+	if p == nil || p.Types == nil {
+		return nil, fmt.Errorf("Package %q could not be loaded", packageName)
 	}
 
-	interfaceName := options.Query[idx+1:]
-	packageName := options.Query[:idx]
-
-	program, err := loadProgram(packageName)
+	interfaceName, args, err := splitTypeArgs(interfaceName)
 	if err != nil {
 		return nil, err
 	}
 
-	pkg := program.Package(packageName).Pkg
+	pkg := p.Types
 	obj := pkg.Scope().Lookup(interfaceName)
 	if obj == nil {
+		if len(p.Errors) > 0 {
+			return nil, fmt.Errorf("Interface %q not found in package %q: %v", interfaceName, packageName, p.Errors[0])
+		}
 		return nil, fmt.Errorf("Interface %q not found in package %q", interfaceName, packageName)
 	}
 
+	typ, typeArgs := obj.Type(), []types.Type(nil)
+	if len(args) > 0 {
+		if typ, typeArgs, err = instantiate(program, obj, args); err != nil {
+			return nil, err
+		}
+	}
+
 	return &Config{
 		InterfaceName:      interfaceName,
 		PackageName:        packageName,
 		Program:            program,
 		Package:            pkg,
 		Object:             obj,
+		Type:               typ,
+		TypeArgs:           typeArgs,
 		WrapperStructName:  wrapperStructName(options.Wrapper, interfaceName),
 		WrapperPackageName: wrapperPackageName(options.Wrapper, packageName),
 		Options:            options,
 	}, nil
 }
 
-func commentText(config *Config, pos token.Pos) string {
-	_, paths, _ := config.Program.PathEnclosingInterval(pos, pos)
+func commentText(program *Program, pos token.Pos) string {
+	_, paths, _ := program.PathEnclosingInterval(pos, pos)
 	for _, n := range paths {
 		switch n := n.(type) {
+		case *ast.TypeSpec:
+			// Types of a grouped declaration have their own doc comment
+			if n.Doc != nil {
+				return commentGroupToString(n.Doc)
+			}
 		case *ast.FuncDecl:
 			return commentGroupToString(n.Doc)
 		case *ast.GenDecl:
@@ -97,6 +425,37 @@ func commentGroupToString(commentGroup *ast.CommentGroup) string {
 	return s
 }
 
+func interfaceTypeParams(config *Config) []TypeParam {
+	named, ok := config.Type.(*types.Named)
+	if !ok || named.TypeParams().Len() == 0 || named.TypeArgs().Len() > 0 {
+		return nil
+	}
+
+	params := make([]TypeParam, 0, named.TypeParams().Len())
+	for i := 0; i < named.TypeParams().Len(); i++ {
+		tp := named.TypeParams().At(i)
+
+		p := TypeParam{Name: tp.Obj().Name()}
+		configureParamType(&p.Constraint, tp.Constraint(), config.importer)
+		params = append(params, p)
+	}
+
+	return params
+}
+
+func interfaceTypeArgs(config *Config) []Type {
+	if len(config.TypeArgs) == 0 {
+		return nil
+	}
+
+	args := make([]Type, len(config.TypeArgs))
+	for i, typ := range config.TypeArgs {
+		configureParamType(&args[i], typ, config.importer)
+	}
+
+	return args
+}
+
 func interfaceFunctions(config *Config, iface *types.Interface) []Func {
 	funcs := []Func{}
 
@@ -110,11 +469,12 @@ func interfaceFunctions(config *Config, iface *types.Interface) []Func {
 
 		f := Func{
 			Name:       meth.Name(),
-			Comment:    commentText(config, meth.Pos()),
-			Params:     signatureVariables(sig.Params(), config.Options.EmptyFunctionParamNamePrefix),
-			Res:        signatureVariables(sig.Results(), config.Options.EmptyFunctionReturnParamNamePrefix),
+			Comment:    commentText(config.Program, meth.Pos()),
+			Params:     signatureVariables(config.importer, sig.Params(), config.Options.EmptyFunctionParamNamePrefix),
+			Res:        signatureVariables(config.importer, sig.Results(), config.Options.EmptyFunctionReturnParamNamePrefix),
 			IsVariadic: sig.Variadic(),
 		}
+		config.redactor.redactFunc(&f, sig)
 
 		funcs = append(funcs, f)
 	}
@@ -122,19 +482,21 @@ func interfaceFunctions(config *Config, iface *types.Interface) []Func {
 	return funcs
 }
 
-func signatureVariables(tuple *types.Tuple, emptyNamePrefix string) []Param {
+func signatureVariables(im *importer, tuple *types.Tuple, emptyNamePrefix string) []Param {
 	params := make([]Param, tuple.Len())
 
 	for i := 0; i < tuple.Len(); i++ {
 		param := tuple.At(i)
 
 		name := param.Name()
-		if name == "" {
+		// Blank names can't be passed on to the wrapped implementation
+		if name == "" || name == "_" {
 			name = fmt.Sprintf("%v%v", emptyNamePrefix, i+1)
 		}
 
 		t := &Type{}
-		configureParamType(t, param.Type())
+		configureParamType(t, param.Type(), im)
+		configureParamKind(t, param.Type())
 
 		params[i] = Param{
 			Name: name,
@@ -145,86 +507,207 @@ func signatureVariables(tuple *types.Tuple, emptyNamePrefix string) []Param {
 	return params
 }
 
-func configureParamType(t *Type, typ types.Type) {
+// configureParamType sets the name of typ qualified relative to the wrapper
+// package and the imports it requires. Type parameters are named like in the
+// interface declaration, as the wrapper declares the same type parameters.
+func configureParamType(t *Type, typ types.Type, im *importer) {
+	t.Name = im.typeString(typ, &t.Imports)
+}
+
+// checkExported returns an error if a method or type parameter constraint of
+// iface uses an unexported type, struct field or interface method. Such methods can only be
+// implemented in the package of the interface.
+func checkExported(config *Config, iface *types.Interface) error {
+	if named, ok := config.Type.(*types.Named); ok && named.TypeArgs().Len() == 0 {
+		for i := 0; i < named.TypeParams().Len(); i++ {
+			tp := named.TypeParams().At(i)
+
+			if name := unexportedName(tp.Constraint(), map[types.Type]bool{}); name != "" {
+				return fmt.Errorf("Type parameter %q of interface %q uses unexported %v and can only be wrapped in package %q", tp.Obj().Name(), config.InterfaceName, name, config.Package.Name())
+			}
+		}
+	}
+
+	for i := 0; i < iface.NumMethods(); i++ {
+		meth := iface.Method(i)
+
+		if name := unexportedName(meth.Type(), map[types.Type]bool{}); name != "" {
+			return fmt.Errorf("Method %q of interface %q uses unexported %v and can only be wrapped in package %q", meth.Name(), config.InterfaceName, name, config.Package.Name())
+		}
+	}
+
+	return nil
+}
+
+// unexportedName returns the first unexported name which typ depends on
+func unexportedName(typ types.Type, seen map[types.Type]bool) string {
+	if seen[typ] {
+		return ""
+	}
+	seen[typ] = true
+
 	switch typ := typ.(type) {
-	case *types.Basic:
-		configureParamTypeName(t, typ.Name())
-	case *types.Pointer:
-		configureParamTypeName(t, typ.String())
-		configureParamType(t, typ.Elem())
 	case *types.Named:
-		if pkg := typ.Obj().Pkg(); pkg != nil {
-			configureParamTypeName(t, fmt.Sprintf("%v.%v", pkg.Name(), typ.Obj().Name()))
-			t.Imports = append(t.Imports, Import{Package: pkg.Name(), Path: pkg.Path()})
+		if typ.Obj().Pkg() != nil && !typ.Obj().Exported() {
+			return fmt.Sprintf("type %v", typ.Obj().Name())
 		}
-		configureParamTypeName(t, typ.Obj().Name())
-	case *types.Slice:
-		configureParamTypeName(t, typ.String())
-		configureParamType(t, typ.Elem())
-	case *types.Signature:
-		configureParamTypeName(t, typ.String())
-		variables := signatureVariables(typ.Params(), "")
-		variables = append(variables, signatureVariables(typ.Results(), "")...)
-		for _, v := range variables {
-			t.Imports = append(t.Imports, v.Type.Imports...)
+		for i := 0; i < typ.TypeArgs().Len(); i++ {
+			if name := unexportedName(typ.TypeArgs().At(i), seen); name != "" {
+				return name
+			}
 		}
+	case *types.Pointer:
+		return unexportedName(typ.Elem(), seen)
+	case *types.Slice:
+		return unexportedName(typ.Elem(), seen)
 	case *types.Array:
-		configureParamTypeName(t, typ.String())
-		configureParamType(t, typ.Elem())
+		return unexportedName(typ.Elem(), seen)
 	case *types.Chan:
-		configureParamTypeName(t, typ.String())
-		configureParamType(t, typ.Elem())
+		return unexportedName(typ.Elem(), seen)
 	case *types.Map:
-		configureParamTypeName(t, typ.String())
-		configureParamType(t, typ.Elem())
+		if name := unexportedName(typ.Key(), seen); name != "" {
+			return name
+		}
+		return unexportedName(typ.Elem(), seen)
+	case *types.Signature:
+		for _, tuple := range []*types.Tuple{typ.Params(), typ.Results()} {
+			for i := 0; i < tuple.Len(); i++ {
+				if name := unexportedName(tuple.At(i).Type(), seen); name != "" {
+					return name
+				}
+			}
+		}
+	case *types.Struct:
+		for i := 0; i < typ.NumFields(); i++ {
+			field := typ.Field(i)
+			if !field.Exported() {
+				return fmt.Sprintf("struct field %v", field.Name())
+			}
+			if name := unexportedName(field.Type(), seen); name != "" {
+				return name
+			}
+		}
+	case *types.Interface:
+		for i := 0; i < typ.NumExplicitMethods(); i++ {
+			meth := typ.ExplicitMethod(i)
+			if !meth.Exported() {
+				return fmt.Sprintf("interface method %v", meth.Name())
+			}
+			if name := unexportedName(meth.Type(), seen); name != "" {
+				return name
+			}
+		}
+		for i := 0; i < typ.NumEmbeddeds(); i++ {
+			if name := unexportedName(typ.EmbeddedType(i), seen); name != "" {
+				return name
+			}
+		}
 	}
+
+	return ""
 }
 
-func configureParamTypeName(t *Type, name string) {
-	if t.Name == "" {
-		t.Name = name
+var (
+	errorInterface    = types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+	stringerInterface = types.NewInterfaceType([]*types.Func{
+		types.NewFunc(token.NoPos, nil, "String", types.NewSignatureType(nil, nil, nil, nil,
+			types.NewTuple(types.NewVar(token.NoPos, nil, "", types.Typ[types.String])), false)),
+	}, nil).Complete()
+)
+
+func configureParamKind(t *Type, typ types.Type) {
+	if named, ok := typ.(*types.Named); ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "context" && named.Obj().Name() == "Context" {
+		t.Kind = KindContext
+		return
 	}
-}
 
-func fixupInterface(inter *Interface, config *Config) {
-	imports := map[string]Import{}
+	if types.Implements(typ, errorInterface) {
+		t.Kind = KindError
+		return
+	}
 
-	for fi, f := range inter.Functions {
-		for pi, p := range f.Params {
-			for _, i := range p.Type.Imports {
-				imports[i.Path] = i
-				inter.Functions[fi].Params[pi].Type.Name = strings.ReplaceAll(p.Type.Name, i.Path, i.Package)
-			}
+	if named, ok := typ.(*types.Named); ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "time" {
+		switch named.Obj().Name() {
+		case "Time":
+			t.Kind = KindTime
+			return
+		case "Duration":
+			t.Kind = KindDuration
+			return
+		}
+	}
 
-			if f.IsVariadic && pi == len(f.Params)-1 {
-				inter.Functions[fi].Params[pi].Type.Name = strings.Replace(p.Type.Name, "[]", "...", 1)
-			}
+	// Pointers are excluded because String() of a nil pointer usually panics
+	if _, ok := typ.(*types.Pointer); !ok && types.Implements(typ, stringerInterface) {
+		t.Kind = KindStringer
+		return
+	}
+
+	switch u := typ.Underlying().(type) {
+	case *types.Slice:
+		// Slices of named byte types can't be converted to []byte
+		if types.Identical(u.Elem(), types.Typ[types.Byte]) {
+			t.Kind = KindBytes
+			t.Basic = "[]byte"
 		}
-		for ri, p := range f.Res {
-			for _, i := range p.Type.Imports {
-				imports[i.Path] = i
-				inter.Functions[fi].Res[ri].Type.Name = strings.ReplaceAll(p.Type.Name, i.Path, i.Package)
-			}
+	case *types.Basic:
+		t.Basic = types.Typ[u.Kind()].Name()
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			t.Kind = KindBool
+		case u.Info()&types.IsString != 0:
+			t.Kind = KindString
+		case u.Info()&types.IsUnsigned != 0:
+			t.Kind = KindUint
+		case u.Info()&types.IsInteger != 0:
+			t.Kind = KindInt
+		case u.Info()&types.IsFloat != 0:
+			t.Kind = KindFloat
+		default:
+			t.Basic = ""
 		}
+	case *types.Signature:
+		t.Kind = KindFunc
+	case *types.Chan:
+		t.Kind = KindChan
 	}
+}
 
-	if config.Package.Name() != config.WrapperPackageName {
-		i := Import{
-			Package: config.Package.Name(),
-			Path:    config.Package.Path(),
+func fixupInterface(inter *Interface, config *Config) {
+	for fi, f := range inter.Functions {
+		if f.IsVariadic {
+			pi := len(f.Params) - 1
+			inter.Functions[fi].Params[pi].Type.Name = strings.Replace(f.Params[pi].Type.Name, "[]", "...", 1)
 		}
+	}
+
+	for fi := range inter.Functions {
+		f := &inter.Functions[fi]
+		f.NoRetry = noRetryAnnotation.MatchString(f.Comment)
+		if traceAnnotation.MatchString(f.Comment) {
+			f.Traced = sortedKeys(annotatedNames(traceAnnotation, f.Comment))
+		}
+		// Annotations are read once and not copied into the generated code
+		f.Comment = stripAnnotations(f.Comment)
+	}
+	for _, i := range redactImports(inter) {
+		config.importer.add(i.Path, i.Package)
+	}
 
-		imports[config.Package.Path()] = i
+	if config.importer.self != config.Package.Path() {
+		i := config.importer.add(config.Package.Path(), config.Package.Name())
 		inter.Name = fmt.Sprintf("%v.%v", i.Package, inter.Name)
 	}
 
-	keys := make([]string, 0, len(imports))
-	for k := range imports {
+	renameParams(inter, config.importer)
+
+	keys := make([]string, 0, len(config.importer.imports))
+	for k := range config.importer.imports {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 
 	for _, k := range keys {
-		inter.Imports = append(inter.Imports, imports[k])
+		inter.Imports = append(inter.Imports, config.importer.imports[k])
 	}
 }
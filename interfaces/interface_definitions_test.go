@@ -1,7 +1,13 @@
 package interfaces
 
 import (
+	"context"
 	"go/ast"
+	"go/token"
+	htmltemplate "html/template"
+	"log"
+	"text/template"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -77,3 +83,120 @@ type EmbeddedInterface interface {
 	EmbeddedInterfaceEmptyFunc()
 	EmbeddedInterfaceFunc() string
 }
+
+// LoggableParamsInterface is a dummy interface to test program
+type LoggableParamsInterface interface {
+	// Loggable param types
+	Loggable(p token.Pos, t time.Time, d time.Duration, b []byte, r rune, f float32) (tok token.Token, err error)
+}
+
+// ContextParamsInterface is a dummy interface to test program
+type ContextParamsInterface interface {
+	// Context param type
+	Context(ctx context.Context, id string) error
+	// Context without error result
+	ContextWithoutError(ctx context.Context)
+}
+
+// RedactedParamsInterface is a dummy interface to test program
+type RedactedParamsInterface interface {
+	// Login authenticates a user.
+	// middleware:redact user
+	Login(ctx context.Context, user string, password string, key uuid.UUID, id int) (accessToken string, err error)
+}
+
+// RedactedTypesInterface is a dummy interface to test program
+type RedactedTypesInterface interface {
+	// Render has a type of an aliased import and a variadic parameter
+	Render(page *htmltemplate.Template, text *template.Template, ids ...uuid.UUID) (html string, err error)
+}
+
+// AnnotatedInterface is a dummy interface to test program
+type AnnotatedInterface interface {
+	// Login authenticates a user.
+	// middleware:redact pin
+	// middleware:trace user
+	//
+	// Login is rate limited.
+	Login(ctx context.Context, user string, pin int) error
+	// Charge isn't idempotent.
+	//middleware:noretry
+	Charge(ctx context.Context, amount int) error
+}
+
+// NoRetryInterface is a dummy interface to test program
+type NoRetryInterface interface {
+	// Charge isn't idempotent.
+	// middleware:noretry
+	Charge(ctx context.Context, amount int) error
+	// Balance can be retried
+	Balance(ctx context.Context) (int, error)
+}
+
+// QualifiedTypesInterface is a dummy interface to test program
+type QualifiedTypesInterface interface {
+	// Type of the interface package
+	Own(kind Kind) *Import
+	// Packages with the same name
+	Templates(text *template.Template, html *htmltemplate.Template)
+	// Package named like an import of the logger
+	Logger(logger *log.Logger) error
+}
+
+// LiteralParamsInterface is a dummy interface to test program
+type LiteralParamsInterface interface {
+	// Struct literal param types
+	Struct(s struct {
+		A int `json:"a"`
+		*ast.Ident
+		uuid.UUID
+	}) struct{}
+	// Interface literal param types
+	Interface(c interface{ Close() error }, e interface{}, n interface {
+		ast.Node
+		Len() int
+	}) any
+	// Func literal param types with multiple results
+	Func(f func(struct{ T time.Time }) (n interface{ ast.Node }, err error))
+	// Variadic struct literal param types
+	Variadic(values ...struct{ D time.Duration })
+}
+
+// UnexportedLiteralParamsInterface is a dummy interface to test program
+type UnexportedLiteralParamsInterface interface {
+	// Struct literal with unexported field
+	Struct(s struct{ a int })
+}
+
+// GenericInterface is a dummy interface to test program
+type GenericInterface[K comparable, V any, N NumberConstraint] interface {
+	// Get with type parameter types
+	Get(ctx context.Context, key K) (V, error)
+	// Put with composite type parameter types
+	Put(key K, values ...V) map[K][]N
+}
+
+// NumberConstraint is a dummy constraint interface to test program
+type NumberConstraint interface {
+	~int | ~float64
+}
+
+// CollidingParamsInterface has parameters named like identifiers of the generated middleware
+type CollidingParamsInterface interface {
+	// Method with parameters named like the receiver, variables and packages
+	Method(l int, begin time.Time, time string, log *log.Logger, uuid uuid.UUID, _ bool, string string) (logger string, err error)
+	// options is named like a field of the wrapper struct
+	options(ctx context.Context, context string) (level int, event error)
+}
+
+// NamedByte is a dummy named byte type to test program
+type NamedByte byte
+
+// NamedBytes is a dummy named byte slice type to test program
+type NamedBytes []byte
+
+// ByteSliceParamsInterface is a dummy interface to test program
+type ByteSliceParamsInterface interface {
+	// Bytes with slices of byte and of a named byte type
+	Bytes(raw []byte, named NamedBytes, elems []NamedByte) error
+}
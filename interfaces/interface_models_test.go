@@ -2,7 +2,7 @@ package interfaces
 
 var ReaderInterface = &Interface{
 	Name:    "io.Reader",
-	Comment: "// Reader is the interface that wraps the basic Read method.\n//\n// Read reads up to len(p) bytes into p. It returns the number of bytes\n// read (0 <= n <= len(p)) and any error encountered. Even if Read\n// returns n < len(p), it may use all of p as scratch space during the call.\n// If some data is available but not len(p) bytes, Read conventionally\n// returns what is available instead of waiting for more.\n//\n// When Read encounters an error or end-of-file condition after\n// successfully reading n > 0 bytes, it returns the number of\n// bytes read. It may return the (non-nil) error from the same call\n// or return the error (and n == 0) from a subsequent call.\n// An instance of this general case is that a Reader returning\n// a non-zero number of bytes at the end of the input stream may\n// return either err == EOF or err == nil. The next Read should\n// return 0, EOF.\n//\n// Callers should always process the n > 0 bytes returned before\n// considering the error err. Doing so correctly handles I/O errors\n// that happen after reading some bytes and also both of the\n// allowed EOF behaviors.\n//\n// Implementations of Read are discouraged from returning a\n// zero byte count with a nil error, except when len(p) == 0.\n// Callers should treat a return of 0 and nil as indicating that\n// nothing happened; in particular it does not indicate EOF.\n//\n// Implementations must not retain p.\n",
+	Comment: "// Reader is the interface that wraps the basic Read method.\n//\n// Read reads up to len(p) bytes into p. It returns the number of bytes\n// read (0 <= n <= len(p)) and any error encountered. Even if Read\n// returns n < len(p), it may use all of p as scratch space during the call.\n// If some data is available but not len(p) bytes, Read conventionally\n// returns what is available instead of waiting for more.\n//\n// When Read encounters an error or end-of-file condition after\n// successfully reading n > 0 bytes, it returns the number of\n// bytes read. It may return the (non-nil) error from the same call\n// or return the error (and n == 0) from a subsequent call.\n// An instance of this general case is that a Reader returning\n// a non-zero number of bytes at the end of the input stream may\n// return either err == EOF or err == nil. The next Read should\n// return 0, EOF.\n//\n// Callers should always process the n > 0 bytes returned before\n// considering the error err. Doing so correctly handles I/O errors\n// that happen after reading some bytes and also both of the\n// allowed EOF behaviors.\n//\n// If len(p) == 0, Read should always return n == 0. It may return a\n// non-nil error if some error condition is known, such as EOF.\n//\n// Implementations of Read are discouraged from returning a\n// zero byte count with a nil error, except when len(p) == 0.\n// Callers should treat a return of 0 and nil as indicating that\n// nothing happened; in particular it does not indicate EOF.\n//\n// Implementations must not retain p.\n",
 	Functions: []Func{
 		{
 			Name: "Read",
@@ -12,6 +12,8 @@ var ReaderInterface = &Interface{
 					Type: Type{
 						Name:    "[]byte",
 						Imports: nil,
+						Kind:    KindBytes,
+						Basic:   "[]byte",
 					},
 				},
 			},
@@ -21,6 +23,8 @@ var ReaderInterface = &Interface{
 					Type: Type{
 						Name:    "int",
 						Imports: nil,
+						Kind:    KindInt,
+						Basic:   "int",
 					},
 				},
 				{
@@ -28,6 +32,7 @@ var ReaderInterface = &Interface{
 					Type: Type{
 						Name:    "error",
 						Imports: nil,
+						Kind:    KindError,
 					},
 				},
 			},
@@ -52,6 +57,8 @@ var TestInterface1Interface = &Interface{
 					Type: Type{
 						Name:    "string",
 						Imports: nil,
+						Kind:    KindString,
+						Basic:   "string",
 					},
 				},
 				{
@@ -59,6 +66,8 @@ var TestInterface1Interface = &Interface{
 					Type: Type{
 						Name:    "string",
 						Imports: nil,
+						Kind:    KindString,
+						Basic:   "string",
 					},
 				},
 			},
@@ -68,6 +77,8 @@ var TestInterface1Interface = &Interface{
 					Type: Type{
 						Name:    "string",
 						Imports: nil,
+						Kind:    KindString,
+						Basic:   "string",
 					},
 				},
 				{
@@ -75,6 +86,7 @@ var TestInterface1Interface = &Interface{
 					Type: Type{
 						Name:    "error",
 						Imports: nil,
+						Kind:    KindError,
 					},
 				},
 			},
@@ -89,6 +101,8 @@ var TestInterface1Interface = &Interface{
 					Type: Type{
 						Name:    "int",
 						Imports: nil,
+						Kind:    KindInt,
+						Basic:   "int",
 					},
 				},
 				{
@@ -96,6 +110,8 @@ var TestInterface1Interface = &Interface{
 					Type: Type{
 						Name:    "int",
 						Imports: nil,
+						Kind:    KindInt,
+						Basic:   "int",
 					},
 				},
 			},
@@ -105,6 +121,8 @@ var TestInterface1Interface = &Interface{
 					Type: Type{
 						Name:    "int",
 						Imports: nil,
+						Kind:    KindInt,
+						Basic:   "int",
 					},
 				},
 				{
@@ -112,6 +130,7 @@ var TestInterface1Interface = &Interface{
 					Type: Type{
 						Name:    "error",
 						Imports: nil,
+						Kind:    KindError,
 					},
 				},
 			},
@@ -126,6 +145,8 @@ var TestInterface1Interface = &Interface{
 					Type: Type{
 						Name:    "bool",
 						Imports: nil,
+						Kind:    KindBool,
+						Basic:   "bool",
 					},
 				},
 				{
@@ -133,6 +154,8 @@ var TestInterface1Interface = &Interface{
 					Type: Type{
 						Name:    "bool",
 						Imports: nil,
+						Kind:    KindBool,
+						Basic:   "bool",
 					},
 				},
 			},
@@ -142,6 +165,8 @@ var TestInterface1Interface = &Interface{
 					Type: Type{
 						Name:    "bool",
 						Imports: nil,
+						Kind:    KindBool,
+						Basic:   "bool",
 					},
 				},
 				{
@@ -149,6 +174,7 @@ var TestInterface1Interface = &Interface{
 					Type: Type{
 						Name:    "error",
 						Imports: nil,
+						Kind:    KindError,
 					},
 				},
 			},
@@ -189,6 +215,8 @@ var UnnammedParametersInterfaceInterface = &Interface{
 					Type: Type{
 						Name:    "string",
 						Imports: nil,
+						Kind:    KindString,
+						Basic:   "string",
 					},
 				},
 			},
@@ -198,6 +226,7 @@ var UnnammedParametersInterfaceInterface = &Interface{
 					Type: Type{
 						Name:    "error",
 						Imports: nil,
+						Kind:    KindError,
 					},
 				},
 			},
@@ -212,6 +241,8 @@ var UnnammedParametersInterfaceInterface = &Interface{
 					Type: Type{
 						Name:    "string",
 						Imports: nil,
+						Kind:    KindString,
+						Basic:   "string",
 					},
 				},
 				{
@@ -219,6 +250,8 @@ var UnnammedParametersInterfaceInterface = &Interface{
 					Type: Type{
 						Name:    "int",
 						Imports: nil,
+						Kind:    KindInt,
+						Basic:   "int",
 					},
 				},
 				{
@@ -226,6 +259,8 @@ var UnnammedParametersInterfaceInterface = &Interface{
 					Type: Type{
 						Name:    "int",
 						Imports: nil,
+						Kind:    KindInt,
+						Basic:   "int",
 					},
 				},
 				{
@@ -233,6 +268,8 @@ var UnnammedParametersInterfaceInterface = &Interface{
 					Type: Type{
 						Name:    "bool",
 						Imports: nil,
+						Kind:    KindBool,
+						Basic:   "bool",
 					},
 				},
 			},
@@ -242,6 +279,8 @@ var UnnammedParametersInterfaceInterface = &Interface{
 					Type: Type{
 						Name:    "bool",
 						Imports: nil,
+						Kind:    KindBool,
+						Basic:   "bool",
 					},
 				},
 				{
@@ -249,6 +288,8 @@ var UnnammedParametersInterfaceInterface = &Interface{
 					Type: Type{
 						Name:    "string",
 						Imports: nil,
+						Kind:    KindString,
+						Basic:   "string",
 					},
 				},
 				{
@@ -256,6 +297,8 @@ var UnnammedParametersInterfaceInterface = &Interface{
 					Type: Type{
 						Name:    "int",
 						Imports: nil,
+						Kind:    KindInt,
+						Basic:   "int",
 					},
 				},
 				{
@@ -263,6 +306,7 @@ var UnnammedParametersInterfaceInterface = &Interface{
 					Type: Type{
 						Name:    "error",
 						Imports: nil,
+						Kind:    KindError,
 					},
 				},
 			},
@@ -277,6 +321,8 @@ var UnnammedParametersInterfaceInterface = &Interface{
 					Type: Type{
 						Name:    "string",
 						Imports: nil,
+						Kind:    KindString,
+						Basic:   "string",
 					},
 				},
 			},
@@ -322,6 +368,7 @@ var ImportedParamTypeInterfaceInterface = &Interface{
 						Imports: []Import{
 							{Package: "uuid", Path: "github.com/google/uuid"},
 						},
+						Kind: KindStringer,
 					},
 				},
 				{
@@ -331,6 +378,7 @@ var ImportedParamTypeInterfaceInterface = &Interface{
 						Imports: []Import{
 							{Package: "uuid", Path: "github.com/google/uuid"},
 						},
+						Kind: KindStringer,
 					},
 				},
 			},
@@ -439,6 +487,8 @@ var VariadicParamTypeInterfaceInterface = &Interface{
 					Type: Type{
 						Name:    "string",
 						Imports: nil,
+						Kind:    KindString,
+						Basic:   "string",
 					},
 				},
 				{
@@ -488,6 +538,7 @@ var FuncTypeParamsInterfaceInterface = &Interface{
 						Imports: []Import{
 							{Package: "ast", Path: "go/ast"},
 						},
+						Kind: KindFunc,
 					},
 				},
 				{
@@ -495,6 +546,8 @@ var FuncTypeParamsInterfaceInterface = &Interface{
 					Type: Type{
 						Name:    "int",
 						Imports: nil,
+						Kind:    KindInt,
+						Basic:   "int",
 					},
 				},
 				{
@@ -502,6 +555,8 @@ var FuncTypeParamsInterfaceInterface = &Interface{
 					Type: Type{
 						Name:    "int",
 						Imports: nil,
+						Kind:    KindInt,
+						Basic:   "int",
 					},
 				},
 			},
@@ -513,6 +568,7 @@ var FuncTypeParamsInterfaceInterface = &Interface{
 						Imports: []Import{
 							{Package: "uuid", Path: "github.com/google/uuid"},
 						},
+						Kind: KindFunc,
 					},
 				},
 			},
@@ -566,6 +622,7 @@ var CompositeParamsInterfaceInterface = &Interface{
 					Type: Type{
 						Name:    "chan string",
 						Imports: nil,
+						Kind:    KindChan,
 					},
 				},
 				{
@@ -573,6 +630,7 @@ var CompositeParamsInterfaceInterface = &Interface{
 					Type: Type{
 						Name:    "<-chan bool",
 						Imports: nil,
+						Kind:    KindChan,
 					},
 				},
 				{
@@ -580,6 +638,7 @@ var CompositeParamsInterfaceInterface = &Interface{
 					Type: Type{
 						Name:    "chan<- int",
 						Imports: nil,
+						Kind:    KindChan,
 					},
 				},
 			},
@@ -589,6 +648,7 @@ var CompositeParamsInterfaceInterface = &Interface{
 					Type: Type{
 						Name:    "chan int",
 						Imports: nil,
+						Kind:    KindChan,
 					},
 				},
 			},
@@ -693,3 +753,317 @@ var CompositeParamsInterfaceInterface = &Interface{
 	WrapperStructName:      "compositeParamsInterface",
 	MiddleWareFunctionName: "WithWrapper",
 }
+
+var LoggableParamsInterfaceInterface = &Interface{
+	Name:    "LoggableParamsInterface",
+	Comment: "// LoggableParamsInterface is a dummy interface to test program\n",
+	Functions: []Func{
+		{
+			Name: "Loggable",
+			Params: []Param{
+				{
+					Name: "p",
+					Type: Type{
+						Name:    "token.Pos",
+						Imports: []Import{{Package: "token", Path: "go/token"}},
+						Kind:    KindInt,
+						Basic:   "int",
+					},
+				},
+				{
+					Name: "t",
+					Type: Type{
+						Name:    "time.Time",
+						Imports: []Import{{Package: "time", Path: "time"}},
+						Kind:    KindTime,
+					},
+				},
+				{
+					Name: "d",
+					Type: Type{
+						Name:    "time.Duration",
+						Imports: []Import{{Package: "time", Path: "time"}},
+						Kind:    KindDuration,
+					},
+				},
+				{
+					Name: "b",
+					Type: Type{
+						Name:  "[]byte",
+						Kind:  KindBytes,
+						Basic: "[]byte",
+					},
+				},
+				{
+					Name: "r",
+					Type: Type{
+						Name:  "rune",
+						Kind:  KindInt,
+						Basic: "int32",
+					},
+				},
+				{
+					Name: "f",
+					Type: Type{
+						Name:  "float32",
+						Kind:  KindFloat,
+						Basic: "float32",
+					},
+				},
+			},
+			Res: []Param{
+				{
+					Name: "tok",
+					Type: Type{
+						Name:    "token.Token",
+						Imports: []Import{{Package: "token", Path: "go/token"}},
+						Kind:    KindStringer,
+					},
+				},
+				{
+					Name: "err",
+					Type: Type{
+						Name: "error",
+						Kind: KindError,
+					},
+				},
+			},
+			Comment: "// Loggable param types\n",
+		},
+	},
+	Imports: []Import{
+		{Package: "token", Path: "go/token"},
+		{Package: "time", Path: "time"},
+	},
+	WrapperPackageName:     "interfaces",
+	WrapperStructName:      "loggableParamsInterface",
+	MiddleWareFunctionName: "WithWrapper",
+}
+
+var ContextParamsInterfaceInterface = &Interface{
+	Name:    "ContextParamsInterface",
+	Comment: "// ContextParamsInterface is a dummy interface to test program\n",
+	Functions: []Func{
+		{
+			Name: "Context",
+			Params: []Param{
+				{
+					Name: "ctx",
+					Type: Type{
+						Name:    "context.Context",
+						Imports: []Import{{Package: "context", Path: "context"}},
+						Kind:    KindContext,
+					},
+				},
+				{
+					Name: "id",
+					Type: Type{
+						Name:  "string",
+						Kind:  KindString,
+						Basic: "string",
+					},
+				},
+			},
+			Res: []Param{
+				{
+					Name: "returnName1",
+					Type: Type{
+						Name: "error",
+						Kind: KindError,
+					},
+				},
+			},
+			Comment: "// Context param type\n",
+		},
+		{
+			Name: "ContextWithoutError",
+			Params: []Param{
+				{
+					Name: "ctx",
+					Type: Type{
+						Name:    "context.Context",
+						Imports: []Import{{Package: "context", Path: "context"}},
+						Kind:    KindContext,
+					},
+				},
+			},
+			Res:     []Param{},
+			Comment: "// Context without error result\n",
+		},
+	},
+	Imports: []Import{
+		{Package: "context", Path: "context"},
+	},
+	WrapperPackageName:     "interfaces",
+	WrapperStructName:      "contextParamsInterface",
+	MiddleWareFunctionName: "WithWrapper",
+}
+
+var LiteralParamsInterfaceInterface = &Interface{
+	Name:    "interfaces.LiteralParamsInterface",
+	Comment: "// LiteralParamsInterface is a dummy interface to test program\n",
+	Functions: []Func{
+		{
+			Name: "Func",
+			Params: []Param{
+				{
+					Name: "f",
+					Type: Type{
+						Name:    "func(struct{T time.Time}) (n interface{ast.Node}, err error)",
+						Imports: []Import{{Package: "time", Path: "time"}, {Package: "ast", Path: "go/ast"}},
+						Kind:    KindFunc,
+					},
+				},
+			},
+			Res:     []Param{},
+			Comment: "// Func literal param types with multiple results\n",
+		},
+		{
+			Name: "Interface",
+			Params: []Param{
+				{
+					Name: "c",
+					Type: Type{Name: "interface{Close() error}"},
+				},
+				{
+					Name: "e",
+					Type: Type{Name: "interface{}"},
+				},
+				{
+					Name: "n",
+					Type: Type{
+						Name:    "interface{Len() int; ast.Node}",
+						Imports: []Import{{Package: "ast", Path: "go/ast"}},
+					},
+				},
+			},
+			Res: []Param{
+				{
+					Name: "returnName1",
+					Type: Type{Name: "any"},
+				},
+			},
+			Comment: "// Interface literal param types\n",
+		},
+		{
+			Name: "Struct",
+			Params: []Param{
+				{
+					Name: "s",
+					Type: Type{
+						Name:    "struct{A int \"json:\\\"a\\\"\"; *ast.Ident; uuid.UUID}",
+						Imports: []Import{{Package: "ast", Path: "go/ast"}, {Package: "uuid", Path: "github.com/google/uuid"}},
+					},
+				},
+			},
+			Res: []Param{
+				{
+					Name: "returnName1",
+					Type: Type{Name: "struct{}"},
+				},
+			},
+			Comment: "// Struct literal param types\n",
+		},
+		{
+			Name: "Variadic",
+			Params: []Param{
+				{
+					Name: "values",
+					Type: Type{
+						Name:    "...struct{D time.Duration}",
+						Imports: []Import{{Package: "time", Path: "time"}},
+					},
+				},
+			},
+			Res:        []Param{},
+			Comment:    "// Variadic struct literal param types\n",
+			IsVariadic: true,
+		},
+	},
+	Imports: []Import{
+		{Package: "uuid", Path: "github.com/google/uuid"},
+		{Package: "interfaces", Path: "github.com/hanofzelbri/middleware-generator/interfaces"},
+		{Package: "ast", Path: "go/ast"},
+		{Package: "time", Path: "time"},
+	},
+	WrapperPackageName:     "tests",
+	WrapperStructName:      "Wrapper",
+	MiddleWareFunctionName: "WithWrapper",
+}
+
+var GenericInterfaceInterface = &Interface{
+	Name:    "interfaces.GenericInterface",
+	Comment: "// GenericInterface is a dummy interface to test program\n",
+	TypeParams: []TypeParam{
+		{Name: "K", Constraint: Type{Name: "comparable"}},
+		{Name: "V", Constraint: Type{Name: "any"}},
+		{
+			Name: "N",
+			Constraint: Type{
+				Name:    "interfaces.NumberConstraint",
+				Imports: []Import{{Package: "interfaces", Path: "github.com/hanofzelbri/middleware-generator/interfaces"}},
+			},
+		},
+	},
+	Functions: []Func{
+		{
+			Name: "Get",
+			Params: []Param{
+				{
+					Name: "ctx",
+					Type: Type{
+						Name:    "context.Context",
+						Imports: []Import{{Package: "context", Path: "context"}},
+						Kind:    KindContext,
+					},
+				},
+				{
+					Name: "key",
+					Type: Type{Name: "K"},
+				},
+			},
+			Res: []Param{
+				{
+					Name: "returnName1",
+					Type: Type{Name: "V"},
+				},
+				{
+					Name: "returnName2",
+					Type: Type{
+						Name: "error",
+						Kind: KindError,
+					},
+				},
+			},
+			Comment: "// Get with type parameter types\n",
+		},
+		{
+			Name: "Put",
+			Params: []Param{
+				{
+					Name: "key",
+					Type: Type{Name: "K"},
+				},
+				{
+					Name: "values",
+					Type: Type{Name: "...V"},
+				},
+			},
+			Res: []Param{
+				{
+					Name: "returnName1",
+					Type: Type{Name: "map[K][]N"},
+				},
+			},
+			Comment:    "// Put with composite type parameter types\n",
+			IsVariadic: true,
+		},
+	},
+	Imports: []Import{
+		{Package: "context", Path: "context"},
+		{Package: "interfaces", Path: "github.com/hanofzelbri/middleware-generator/interfaces"},
+	},
+	WrapperPackageName:     "tests",
+	WrapperStructName:      "Wrapper",
+	MiddleWareFunctionName: "WithWrapper",
+}
@@ -0,0 +1,8 @@
+//go:build middlewaretags
+
+package interfaces
+
+// TaggedInterface is a dummy interface which is only visible with build tag middlewaretags
+type TaggedInterface interface {
+	Tagged()
+}
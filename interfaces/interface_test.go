@@ -1,7 +1,9 @@
 package interfaces
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -101,6 +103,66 @@ func TestBuildInterface(t *testing.T) {
 			want:    CompositeParamsInterfaceInterface,
 			wantErr: false,
 		},
+		{
+			name: "github.com/hanofzelbri/middleware-generator/interfaces.LoggableParamsInterface",
+			options: func() Options {
+				o.Query = "github.com/hanofzelbri/middleware-generator/interfaces.LoggableParamsInterface"
+				o.Wrapper = ""
+				return o
+			},
+			want:    LoggableParamsInterfaceInterface,
+			wantErr: false,
+		},
+		{
+			name: "github.com/hanofzelbri/middleware-generator/interfaces.ContextParamsInterface",
+			options: func() Options {
+				o.Query = "github.com/hanofzelbri/middleware-generator/interfaces.ContextParamsInterface"
+				o.Wrapper = ""
+				return o
+			},
+			want:    ContextParamsInterfaceInterface,
+			wantErr: false,
+		},
+		{
+			name: "github.com/hanofzelbri/middleware-generator/interfaces.LiteralParamsInterface",
+			options: func() Options {
+				o.Query = "github.com/hanofzelbri/middleware-generator/interfaces.LiteralParamsInterface"
+				o.Wrapper = "tests.Wrapper"
+				return o
+			},
+			want:    LiteralParamsInterfaceInterface,
+			wantErr: false,
+		},
+		{
+			name: "github.com/hanofzelbri/middleware-generator/interfaces.UnexportedLiteralParamsInterface",
+			options: func() Options {
+				o.Query = "github.com/hanofzelbri/middleware-generator/interfaces.UnexportedLiteralParamsInterface"
+				o.Wrapper = "tests.Wrapper"
+				return o
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "github.com/hanofzelbri/middleware-generator/interfaces.GenericInterface",
+			options: func() Options {
+				o.Query = "github.com/hanofzelbri/middleware-generator/interfaces.GenericInterface"
+				o.Wrapper = "tests.Wrapper"
+				return o
+			},
+			want:    GenericInterfaceInterface,
+			wantErr: false,
+		},
+		{
+			name: "github.com/hanofzelbri/middleware-generator/interfaces.NumberConstraint",
+			options: func() Options {
+				o.Query = "github.com/hanofzelbri/middleware-generator/interfaces.NumberConstraint"
+				o.Wrapper = ""
+				return o
+			},
+			want:    nil,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -110,3 +172,547 @@ func TestBuildInterface(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildInterfaceTags(t *testing.T) {
+	o := Options{
+		Query:                              "github.com/hanofzelbri/middleware-generator/interfaces.TaggedInterface",
+		MiddlewareFunctionName:             "WithWrapper",
+		EmptyFunctionParamNamePrefix:       "param",
+		EmptyFunctionReturnParamNamePrefix: "ret",
+	}
+
+	_, err := BuildInterface(o)
+	assert.Error(t, err)
+
+	o.Tags = []string{"middlewaretags"}
+	got, err := BuildInterface(o)
+	assert.NoError(t, err)
+	if assert.NotNil(t, got) {
+		assert.Equal(t, "taggedInterface", got.WrapperStructName)
+		assert.Len(t, got.Functions, 1)
+	}
+}
+
+func TestBuildInterfaceInvalidLevel(t *testing.T) {
+	_, err := BuildInterface(Options{Query: "io.Reader", ErrorLevel: "fatal"})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `Unknown log level "fatal"`)
+	}
+}
+
+func TestBuildInterfaceInvalidKind(t *testing.T) {
+	_, err := BuildInterface(Options{Query: "io.Reader", Kind: "caching"})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `Unknown middleware kind "caching"`)
+	}
+}
+
+func TestBuildInterfaceTemplateKind(t *testing.T) {
+	_, err := BuildInterface(Options{Query: "io.Reader", Kind: MiddlewareRetry, Templates: []string{"middleware.tmpl"}})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `can't be combined with middleware kind "retry"`)
+	}
+}
+
+func TestBuildInterfaceRedaction(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		options Options
+		want    map[string]bool
+		imports []Import
+		wantErr bool
+	}{
+		{
+			name:    "nothing configured",
+			options: Options{},
+			want:    map[string]bool{"user": true, "password": true, "accessToken": true},
+		},
+		{
+			name:    "pattern disabled",
+			options: Options{NoRedactPattern: true},
+			want:    map[string]bool{"user": true},
+		},
+		{
+			name:    "configured pattern",
+			options: Options{RedactPattern: "^key$"},
+			want:    map[string]bool{"user": true, "key": true},
+		},
+		{
+			name:    "pattern names and types",
+			options: Options{Redact: []string{"uuid.UUID", "id"}, RedactMode: RedactModeHash},
+			want:    map[string]bool{"user": true, "password": true, "key": true, "id": true, "accessToken": true},
+			imports: []Import{{Package: "sha256", Path: "crypto/sha256"}, {Package: "fmt", Path: "fmt"}},
+		},
+		{
+			name:    "types of aliased imports, variadic params and errors",
+			query:   "RedactedTypesInterface",
+			options: Options{Redact: []string{"*html/template.Template", "uuid.UUID", "error"}, NoRedactPattern: true},
+			want:    map[string]bool{"page": true, "ids": true, "err": true},
+		},
+		{
+			name:    "types qualified by package name",
+			query:   "RedactedTypesInterface",
+			options: Options{Redact: []string{"*template.Template"}, NoRedactPattern: true},
+			want:    map[string]bool{"page": true, "text": true},
+		},
+		{
+			name:    "length mode",
+			options: Options{RedactMode: RedactModeLength, NoRedactPattern: true},
+			want:    map[string]bool{"user": true},
+			imports: []Import{{Package: "fmt", Path: "fmt"}},
+		},
+		{
+			name:    "invalid pattern",
+			options: Options{RedactPattern: "("},
+			wantErr: true,
+		},
+		{
+			name:    "invalid mode",
+			options: Options{RedactMode: "base64"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.options.Query = "github.com/hanofzelbri/middleware-generator/interfaces." + defaultString(tt.query, "RedactedParamsInterface")
+			got, err := BuildInterface(tt.options)
+			assert.Equal(t, tt.wantErr, err != nil, "%v", err)
+			if err != nil {
+				return
+			}
+
+			redacted := map[string]bool{}
+			for _, params := range [][]Param{got.Functions[0].Params, got.Functions[0].Res} {
+				for _, p := range params {
+					if p.Redacted {
+						redacted[p.Name] = true
+					}
+				}
+			}
+			assert.Equal(t, tt.want, redacted)
+			for _, i := range tt.imports {
+				assert.Contains(t, got.Imports, i)
+			}
+		})
+	}
+}
+
+func TestBuildInterfaceNoRetry(t *testing.T) {
+	got, err := BuildInterface(Options{
+		Query:                              "github.com/hanofzelbri/middleware-generator/interfaces.NoRetryInterface",
+		Kind:                               MiddlewareRetry,
+		EmptyFunctionReturnParamNamePrefix: "ret",
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	noRetry := map[string]bool{}
+	for _, f := range got.Functions {
+		noRetry[f.Name] = f.NoRetry
+	}
+	assert.Equal(t, map[string]bool{"Balance": false, "Charge": true}, noRetry)
+}
+
+func TestBuildInterfaceAnnotations(t *testing.T) {
+	got, err := BuildInterface(Options{
+		Query:                              "github.com/hanofzelbri/middleware-generator/interfaces.AnnotatedInterface",
+		EmptyFunctionReturnParamNamePrefix: "ret",
+	})
+	if !assert.NoError(t, err) || !assert.Len(t, got.Functions, 2) {
+		return
+	}
+
+	charge, login := got.Functions[0], got.Functions[1]
+	assert.Equal(t, "// Charge isn't idempotent.\n", charge.Comment)
+	assert.True(t, charge.NoRetry)
+	assert.Nil(t, charge.Traced)
+	assert.Equal(t, "// Login authenticates a user.\n//\n// Login is rate limited.\n", login.Comment)
+	assert.Equal(t, []string{"user"}, login.Traced)
+	assert.True(t, login.Params[2].Redacted)
+}
+
+func TestStripAnnotations(t *testing.T) {
+	comment := "// Get an entry.\n// middleware:redact key, token\n//middleware:noretry\n// middleware:trace key\n// middleware:tracer isn't an annotation\n/* middleware:noretry */\n"
+
+	assert.Equal(t, "// Get an entry.\n// middleware:tracer isn't an annotation\n/* middleware:noretry */\n", stripAnnotations(comment))
+}
+
+func TestBuildInterfaceTimeouts(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  Options
+		want     time.Duration
+		wantFunc time.Duration
+		wantErr  string
+	}{
+		{
+			name:    "default",
+			options: Options{},
+		},
+		{
+			name:     "configured",
+			options:  Options{Timeout: "5s", Timeouts: map[string]string{"Context": "250ms"}},
+			want:     5 * time.Second,
+			wantFunc: 250 * time.Millisecond,
+		},
+		{
+			name:    "invalid",
+			options: Options{Timeout: "-1s"},
+			wantErr: `Invalid timeout "-1s"`,
+		},
+		{
+			name:    "unknown method",
+			options: Options{Timeouts: map[string]string{"Contex": "1s"}},
+			wantErr: "Timeouts configured for unknown methods: Contex",
+		},
+		{
+			name:    "method without context",
+			options: Options{Query: "github.com/hanofzelbri/middleware-generator/interfaces.LoggableParamsInterface", Timeouts: map[string]string{"Loggable": "1s"}},
+			wantErr: "Timeouts configured for methods without context.Context parameter: Loggable",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.options.Query == "" {
+				tt.options.Query = "github.com/hanofzelbri/middleware-generator/interfaces.ContextParamsInterface"
+			}
+			tt.options.Kind = MiddlewareTimeout
+			got, err := BuildInterface(tt.options)
+			if tt.wantErr != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tt.wantErr)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.Timeout)
+			assert.Equal(t, tt.wantFunc, got.Functions[0].Timeout)
+		})
+	}
+}
+
+func TestBuildInterfaces(t *testing.T) {
+	tests := []struct {
+		name    string
+		queries []string
+		match   string
+		want    []string
+		structs []string
+		wantErr bool
+	}{
+		{
+			name:    "single query",
+			queries: []string{"github.com/hanofzelbri/middleware-generator/interfaces.EmptyInterface"},
+			want:    []string{"EmptyInterface"},
+			structs: []string{"wrapper"},
+		},
+		{
+			name: "multiple queries",
+			queries: []string{
+				"github.com/hanofzelbri/middleware-generator/interfaces.EmptyInterface",
+				"io.Reader",
+			},
+			want:    []string{"EmptyInterface", "io.Reader"},
+			structs: []string{"emptyInterface", "reader"},
+		},
+		{
+			name:    "wildcard with match",
+			queries: []string{"github.com/hanofzelbri/middleware-generator/interfaces.*"},
+			match:   "^(Context|Loggable)Params",
+			want:    []string{"ContextParamsInterface", "LoggableParamsInterface"},
+			structs: []string{"contextParamsInterface", "loggableParamsInterface"},
+		},
+		{
+			name:    "wildcard without match",
+			queries: []string{"github.com/hanofzelbri/middleware-generator/interfaces.*"},
+			match:   "^NotExisting$",
+			wantErr: true,
+		},
+		{
+			name:    "invalid match",
+			queries: []string{"github.com/hanofzelbri/middleware-generator/interfaces.*"},
+			match:   "(",
+			wantErr: true,
+		},
+		{
+			name:    "invalid query",
+			queries: []string{"EmptyInterface"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := Options{
+				Queries:                            tt.queries,
+				Match:                              tt.match,
+				Wrapper:                            "interfaces.wrapper",
+				MiddlewareFunctionName:             "WithWrapper",
+				EmptyFunctionParamNamePrefix:       "param",
+				EmptyFunctionReturnParamNamePrefix: "ret",
+			}
+
+			got, err := BuildInterfaces(o)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			names, structs := []string{}, []string{}
+			for _, inter := range got {
+				names = append(names, inter.Name)
+				structs = append(structs, inter.WrapperStructName)
+
+				functionName := "WithWrapper"
+				if len(got) > 1 {
+					functionName += inter.Name[strings.LastIndex(inter.Name, ".")+1:]
+				}
+				assert.Equal(t, functionName, inter.MiddleWareFunctionName)
+			}
+			assert.Equal(t, tt.want, names)
+			assert.Equal(t, tt.structs, structs)
+		})
+	}
+}
+
+func TestBuildInterfaceQualifiedTypes(t *testing.T) {
+	tests := []struct {
+		name    string
+		wrapper string
+		logger  string
+		iface   string
+		types   []string
+		imports []Import
+	}{
+		{
+			name:    "interface package",
+			wrapper: "",
+			logger:  LoggerSlog,
+			iface:   "QualifiedTypesInterface",
+			types:   []string{"*log.Logger", "error", "Kind", "*Import", "*template.Template", "*template2.Template"},
+			imports: []Import{
+				{Package: "template2", Path: "html/template"},
+				{Package: "log", Path: "log"},
+				{Package: "template", Path: "text/template"},
+			},
+		},
+		{
+			name:    "other package",
+			wrapper: "tests.Wrapper",
+			logger:  LoggerZerolog,
+			iface:   "interfaces.QualifiedTypesInterface",
+			types:   []string{"*log2.Logger", "error", "interfaces.Kind", "*interfaces.Import", "*template.Template", "*template2.Template"},
+			imports: []Import{
+				{Package: "interfaces", Path: "github.com/hanofzelbri/middleware-generator/interfaces"},
+				{Package: "template2", Path: "html/template"},
+				{Package: "log2", Path: "log"},
+				{Package: "template", Path: "text/template"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuildInterface(Options{
+				Query:   "github.com/hanofzelbri/middleware-generator/interfaces.QualifiedTypesInterface",
+				Wrapper: tt.wrapper,
+				Logger:  tt.logger,
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			types := []string{}
+			for _, f := range got.Functions {
+				for _, params := range [][]Param{f.Params, f.Res} {
+					for _, p := range params {
+						types = append(types, p.Type.Name)
+					}
+				}
+			}
+			assert.Equal(t, tt.iface, got.Name)
+			assert.Equal(t, tt.types, types)
+			assert.Equal(t, tt.imports, got.Imports)
+		})
+	}
+}
+
+func TestBuildInterfaceUnexportedLiteral(t *testing.T) {
+	o := Options{Query: "github.com/hanofzelbri/middleware-generator/interfaces.UnexportedLiteralParamsInterface"}
+
+	got, err := BuildInterface(o)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "struct{a int}", got.Functions[0].Params[0].Type.Name)
+	}
+
+	o.Wrapper = "tests.Wrapper"
+	_, err = BuildInterface(o)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "unexported struct field a")
+	}
+}
+
+func TestBuildInterfaceInstantiation(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		typeName string
+		types    []string
+		imports  []Import
+		wantErr  bool
+	}{
+		{
+			name:     "package names",
+			query:    "github.com/hanofzelbri/middleware-generator/interfaces.GenericInterface[string, *time.Time, int]",
+			typeName: "interfaces.GenericInterface[string, *time.Time, int]",
+			types:    []string{"context.Context", "string", "*time.Time", "error", "string", "...*time.Time", "map[string][]int"},
+			imports: []Import{
+				{Package: "context", Path: "context"},
+				{Package: "interfaces", Path: "github.com/hanofzelbri/middleware-generator/interfaces"},
+				{Package: "time", Path: "time"},
+			},
+		},
+		{
+			name:     "import paths",
+			query:    "github.com/hanofzelbri/middleware-generator/interfaces.GenericInterface[github.com/google/uuid.UUID,map[string]github.com/hanofzelbri/middleware-generator/interfaces.Kind,float64]",
+			typeName: "interfaces.GenericInterface[uuid.UUID, map[string]interfaces.Kind, float64]",
+			types:    []string{"context.Context", "uuid.UUID", "map[string]interfaces.Kind", "error", "uuid.UUID", "...map[string]interfaces.Kind", "map[uuid.UUID][]float64"},
+			imports: []Import{
+				{Package: "context", Path: "context"},
+				{Package: "uuid", Path: "github.com/google/uuid"},
+				{Package: "interfaces", Path: "github.com/hanofzelbri/middleware-generator/interfaces"},
+			},
+		},
+		{
+			name:    "unsatisfied constraint",
+			query:   "github.com/hanofzelbri/middleware-generator/interfaces.GenericInterface[[]int, int, int]",
+			wantErr: true,
+		},
+		{
+			name:    "wrong number of type arguments",
+			query:   "github.com/hanofzelbri/middleware-generator/interfaces.GenericInterface[int, int]",
+			wantErr: true,
+		},
+		{
+			name:    "not generic",
+			query:   "github.com/hanofzelbri/middleware-generator/interfaces.EmptyInterface[int]",
+			wantErr: true,
+		},
+		{
+			name:    "unknown package",
+			query:   "github.com/hanofzelbri/middleware-generator/interfaces.GenericInterface[int, unknown.Type, int]",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuildInterface(Options{Query: tt.query, Wrapper: "tests.Wrapper"})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			types := []string{}
+			for _, f := range got.Functions {
+				for _, params := range [][]Param{f.Params, f.Res} {
+					for _, p := range params {
+						types = append(types, p.Type.Name)
+					}
+				}
+			}
+			assert.Equal(t, "interfaces.GenericInterface", got.Name)
+			assert.Equal(t, tt.typeName, got.TypeName())
+			assert.Empty(t, got.TypeParams)
+			assert.Equal(t, tt.types, types)
+			assert.Equal(t, tt.imports, got.Imports)
+		})
+	}
+}
+
+func TestBuildInterfaceByteSlices(t *testing.T) {
+	options := Options{
+		Query:                              "github.com/hanofzelbri/middleware-generator/interfaces.ByteSliceParamsInterface",
+		Wrapper:                            "interfaces.zzByteSlices",
+		MiddlewareFunctionName:             "WithZZByteSlices",
+		EmptyFunctionReturnParamNamePrefix: "ret",
+		Logger:                             LoggerSlog,
+	}
+	got, err := BuildInterface(options)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	kinds := []Kind{}
+	for _, p := range got.Functions[0].Params {
+		kinds = append(kinds, p.Type.Kind)
+	}
+	assert.Equal(t, []Kind{KindBytes, KindBytes, KindOther}, kinds)
+
+	content, err := InterfaceWrapperTemplate(got)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NoError(t, TypeCheck(options, map[string][]byte{"zz_byte_slices_test.go": content}))
+}
+
+func TestBuildInterfaceCollidingParams(t *testing.T) {
+	tests := []struct {
+		logger   string
+		names    [][]string
+		contains []string
+	}{
+		{
+			logger: LoggerZerolog,
+			names: [][]string{
+				{"l2", "begin2", "time2", "log3", "uuid2", "param6", "string2", "logger2", "err"},
+				{"ctx", "context2", "level2", "event2"},
+			},
+			contains: []string{`Int("l", l2)`, `Time("begin", begin2)`, `Str("time", time2)`, `Str("logger", logger2)`},
+		},
+		{
+			logger: LoggerSlog,
+			names: [][]string{
+				{"l2", "begin2", "time2", "log2", "uuid2", "param6", "string2", "logger2", "err"},
+				{"ctx", "context2", "level2", "event2"},
+			},
+			contains: []string{`slog.Int64("l", int64(l2))`, `slog.String("time", time2)`, `slog.Any("event", event2)`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.logger, func(t *testing.T) {
+			got, err := BuildInterface(Options{
+				Query:                        "github.com/hanofzelbri/middleware-generator/interfaces.CollidingParamsInterface",
+				MiddlewareFunctionName:       "WithMiddleware",
+				EmptyFunctionParamNamePrefix: "param",
+				Logger:                       tt.logger,
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			names := [][]string{}
+			for _, f := range got.Functions {
+				n := []string{}
+				for _, params := range [][]Param{f.Params, f.Res} {
+					for _, p := range params {
+						n = append(n, p.Name)
+					}
+				}
+				names = append(names, n)
+			}
+			assert.Equal(t, tt.names, names)
+
+			content, err := InterfaceWrapperTemplate(got)
+			if !assert.NoError(t, err) {
+				return
+			}
+			for _, c := range tt.contains {
+				assert.Contains(t, string(content), c)
+			}
+		})
+	}
+}
@@ -0,0 +1,621 @@
+package interfaces
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// Supported logging libraries of the generated middleware
+const (
+	LoggerZerolog = "zerolog"
+	LoggerSlog    = "slog"
+	LoggerZap     = "zap"
+	LoggerLogrus  = "logrus"
+	LoggerStdlib  = "log"
+	LoggerGoKit   = "gokit"
+)
+
+// Supported log levels of the generated middleware
+const (
+	LevelDebug = "debug"
+	LevelInfo  = "info"
+	LevelWarn  = "warn"
+	LevelError = "error"
+)
+
+// Levels returns the names of all supported log levels
+func Levels() []string {
+	return []string{LevelDebug, LevelInfo, LevelWarn, LevelError}
+}
+
+func validateLevel(level string) error {
+	if level == "" {
+		return nil
+	}
+
+	for _, l := range Levels() {
+		if l == level {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("Unknown log level %q, supported levels are: %v", level, strings.Join(Levels(), ", "))
+}
+
+// loggerBackend contains the backend specific part of the logging template.
+//
+// Every backend template defines:
+//   - "loggerType": type of the injectable logger
+//   - "defaultLogger": global logger used if none is injected, it is looked up
+//     on every call so that replacing the global logger takes effect
+//   - "fieldsType": type storing the base fields
+//   - "fieldsParam": parameter type of the fields option
+//   - "addFields": statements adding fields to options o
+//   - "withFields": statements adding the base fields of options o to logger
+//   - "applyFields": statements applying the base fields to options
+//   - "level": selects the level function depending on the returned error
+//   - "log": logs the call, executed with the Func which is logged
+type loggerBackend struct {
+	imports []Import
+	tmpl    string
+}
+
+var loggerBackends = map[string]loggerBackend{
+	LoggerZerolog: {
+		imports: []Import{
+			{Package: "zerolog", Path: "github.com/rs/zerolog"},
+			{Package: "log", Path: "github.com/rs/zerolog/log"},
+		},
+		tmpl: zerologTmpl,
+	},
+	LoggerSlog: {
+		imports: []Import{{Package: "slog", Path: "log/slog"}},
+		tmpl:    slogTmpl,
+	},
+	LoggerZap: {
+		imports: []Import{{Package: "zap", Path: "go.uber.org/zap"}},
+		tmpl:    zapTmpl,
+	},
+	LoggerLogrus: {
+		imports: []Import{{Package: "logrus", Path: "github.com/sirupsen/logrus"}},
+		tmpl:    logrusTmpl,
+	},
+	LoggerStdlib: {
+		imports: []Import{
+			{Package: "fmt", Path: "fmt"},
+			{Package: "log", Path: "log"},
+		},
+		tmpl: stdlibTmpl,
+	},
+	LoggerGoKit: {
+		imports: []Import{
+			{Package: "os", Path: "os"},
+			{Package: "log", Path: "github.com/go-kit/log"},
+			{Package: "level", Path: "github.com/go-kit/log/level"},
+		},
+		tmpl: gokitTmpl,
+	},
+}
+
+// Loggers returns the names of all supported logging libraries
+func Loggers() []string {
+	loggers := make([]string, 0, len(loggerBackends))
+	for k := range loggerBackends {
+		loggers = append(loggers, k)
+	}
+	sort.Strings(loggers)
+
+	return loggers
+}
+
+func loggerTemplate(logger string) (loggerBackend, error) {
+	if logger == "" {
+		logger = LoggerZerolog
+	}
+
+	b, ok := loggerBackends[logger]
+	if !ok {
+		return loggerBackend{}, fmt.Errorf("Unknown logger %q, supported loggers are: %v", logger, strings.Join(Loggers(), ", "))
+	}
+
+	return b, nil
+}
+
+// templateFuncs returns the functions available in all templates. The level
+// functions return the log levels of i with defaults applied, imports returns
+// the imports of i merged with the imports of the template. The field
+// functions return the names of the wrapper struct fields.
+func templateFuncs(i *Interface, imports []Import) template.FuncMap {
+	level := defaultString(i.Level, LevelInfo)
+
+	return template.FuncMap{
+		"imports":        func() []Import { return mergeImports(i.Imports, imports) },
+		"loggable":       loggable,
+		"value":          func(p Param) string { return logValue(p, i.RedactMode) },
+		"zerologField":   func(p Param) string { return zerologField(p, i.RedactMode) },
+		"slogAttr":       func(p Param) string { return slogAttr(p, i.RedactMode) },
+		"zapField":       func(p Param) string { return zapField(p, i.RedactMode) },
+		"otelAttributes": func(f Func) []string { return otelAttributes(f, i.RedactMode) },
+		"retryable":      retryable,
+		"durationExpr":   durationExpr,
+		"defaultTimeout": func() string { return durationExpr(defaultDuration(i.Timeout, DefaultTimeout)) },
+		"title":          title,
+		"base":           path.Base,
+		"upper":          strings.ToUpper,
+		"defaultLevel":   func() string { return level },
+		"successLevel":   func() string { return defaultString(i.SuccessLevel, level) },
+		"errorLevel":     func() string { return defaultString(i.ErrorLevel, LevelError) },
+		"wrapperField":   func() string { return fieldName(i, "wrapper") },
+		"optionsField":   func() string { return fieldName(i, "options") },
+		"field":          func(name string) string { return fieldName(i, name) },
+	}
+}
+
+// mergeImports returns imports and the generally required time package
+// followed by additional, skipping already imported paths
+func mergeImports(imports []Import, additional []Import) []Import {
+	merged := []Import{{Package: "time", Path: "time"}}
+	seen := map[string]bool{"time": true}
+
+	for _, imps := range [][]Import{imports, additional} {
+		for _, imp := range imps {
+			if !seen[imp.Path] {
+				seen[imp.Path] = true
+				merged = append(merged, imp)
+			}
+		}
+	}
+
+	return merged
+}
+
+func defaultString(s string, def string) string {
+	if s == "" {
+		return def
+	}
+
+	return s
+}
+
+func title(s string) string {
+	if s == "" {
+		return s
+	}
+
+	return string(unicode.ToUpper(rune(s[0]))) + s[1:]
+}
+
+// loggable reports whether the value of p can be logged at all. Contexts
+// are not logged, they are used to retrieve the logger if supported.
+func loggable(p Param) bool {
+	return p.Type.Kind != KindFunc && p.Type.Kind != KindChan && p.Type.Kind != KindContext
+}
+
+// convert returns the expression converting p to type typ
+func convert(p Param, typ string) string {
+	if p.Type.Name == typ {
+		return p.Name
+	}
+
+	return fmt.Sprintf("%v(%v)", typ, p.Name)
+}
+
+// logValue returns the expression logged for p
+func logValue(p Param, redactMode string) string {
+	if p.Redacted {
+		return redactedValue(p, redactMode)
+	}
+
+	return p.Name
+}
+
+// zerologField returns the zerolog event method logging p. An empty string
+// is returned for values which can't be logged.
+func zerologField(p Param, redactMode string) string {
+	if p.Redacted && loggable(p) {
+		return fmt.Sprintf("Str(%q, %v)", p.Key(), redactedValue(p, redactMode))
+	}
+
+	switch p.Type.Kind {
+	case KindString:
+		return fmt.Sprintf("Str(%q, %v)", p.Key(), convert(p, "string"))
+	case KindBool:
+		return fmt.Sprintf("Bool(%q, %v)", p.Key(), convert(p, "bool"))
+	case KindInt, KindUint, KindFloat:
+		basic := p.Type.Basic
+		if basic == "uintptr" {
+			basic = "uint64"
+		}
+		return fmt.Sprintf("%v(%q, %v)", title(basic), p.Key(), convert(p, basic))
+	case KindError:
+		return fmt.Sprintf("AnErr(%q, %v)", p.Key(), p.Name)
+	case KindStringer:
+		return fmt.Sprintf("Stringer(%q, %v)", p.Key(), p.Name)
+	case KindTime:
+		return fmt.Sprintf("Time(%q, %v)", p.Key(), p.Name)
+	case KindDuration:
+		return fmt.Sprintf("Dur(%q, %v)", p.Key(), p.Name)
+	case KindBytes:
+		return fmt.Sprintf("Bytes(%q, %v)", p.Key(), convert(p, "[]byte"))
+	case KindFunc, KindChan, KindContext:
+		return ""
+	}
+
+	return fmt.Sprintf("Interface(%q, %v)", p.Key(), p.Name)
+}
+
+// slogAttr returns the slog.Attr logging p. An empty string is returned for
+// values which can't be logged.
+func slogAttr(p Param, redactMode string) string {
+	if p.Redacted && loggable(p) {
+		return fmt.Sprintf("slog.String(%q, %v)", p.Key(), redactedValue(p, redactMode))
+	}
+
+	switch p.Type.Kind {
+	case KindString:
+		return fmt.Sprintf("slog.String(%q, %v)", p.Key(), convert(p, "string"))
+	case KindBool:
+		return fmt.Sprintf("slog.Bool(%q, %v)", p.Key(), convert(p, "bool"))
+	case KindInt:
+		return fmt.Sprintf("slog.Int64(%q, %v)", p.Key(), convert(p, "int64"))
+	case KindUint:
+		return fmt.Sprintf("slog.Uint64(%q, %v)", p.Key(), convert(p, "uint64"))
+	case KindFloat:
+		return fmt.Sprintf("slog.Float64(%q, %v)", p.Key(), convert(p, "float64"))
+	case KindTime:
+		return fmt.Sprintf("slog.Time(%q, %v)", p.Key(), p.Name)
+	case KindDuration:
+		return fmt.Sprintf("slog.Duration(%q, %v)", p.Key(), p.Name)
+	case KindBytes:
+		return fmt.Sprintf("slog.String(%q, string(%v))", p.Key(), p.Name)
+	case KindFunc, KindChan, KindContext:
+		return ""
+	}
+
+	return fmt.Sprintf("slog.Any(%q, %v)", p.Key(), p.Name)
+}
+
+// zapField returns the zap.Field logging p. An empty string is returned for
+// values which can't be logged.
+func zapField(p Param, redactMode string) string {
+	if p.Redacted && loggable(p) {
+		return fmt.Sprintf("zap.String(%q, %v)", p.Key(), redactedValue(p, redactMode))
+	}
+
+	switch p.Type.Kind {
+	case KindString:
+		return fmt.Sprintf("zap.String(%q, %v)", p.Key(), convert(p, "string"))
+	case KindBool:
+		return fmt.Sprintf("zap.Bool(%q, %v)", p.Key(), convert(p, "bool"))
+	case KindInt:
+		return fmt.Sprintf("zap.Int64(%q, %v)", p.Key(), convert(p, "int64"))
+	case KindUint:
+		return fmt.Sprintf("zap.Uint64(%q, %v)", p.Key(), convert(p, "uint64"))
+	case KindFloat:
+		return fmt.Sprintf("zap.Float64(%q, %v)", p.Key(), convert(p, "float64"))
+	case KindError:
+		return fmt.Sprintf("zap.NamedError(%q, %v)", p.Key(), p.Name)
+	case KindStringer:
+		return fmt.Sprintf("zap.Stringer(%q, %v)", p.Key(), p.Name)
+	case KindTime:
+		return fmt.Sprintf("zap.Time(%q, %v)", p.Key(), p.Name)
+	case KindDuration:
+		return fmt.Sprintf("zap.Duration(%q, %v)", p.Key(), p.Name)
+	case KindBytes:
+		return fmt.Sprintf("zap.ByteString(%q, %v)", p.Key(), convert(p, "[]byte"))
+	case KindFunc, KindChan, KindContext:
+		return ""
+	}
+
+	return fmt.Sprintf("zap.Any(%q, %v)", p.Key(), p.Name)
+}
+
+var zerologTmpl = `
+{{define "loggerType"}}zerolog.Logger{{end}}
+{{define "defaultLogger"}}log.Logger{{end}}
+{{define "fieldsType"}}map[string]interface{}{{end}}
+{{define "fieldsParam"}}map[string]interface{}{{end}}
+
+{{define "addFields"}}
+        if o.fields == nil {
+            o.fields = map[string]interface{}{}
+        }
+        for k, v := range fields {
+            o.fields[k] = v
+        }
+{{- end}}
+
+{{define "withFields"}}
+    if len(o.fields) > 0 {
+        logger = logger.With().Fields(o.fields).Logger()
+    }
+{{- end}}
+
+{{define "applyFields"}}{{end}}
+
+{{define "logger"}}
+        logger := l.{{optionsField}}.currentLogger()
+        {{- with .ContextParam}}
+        if {{.Name}} != nil {
+            if ctxLogger := zerolog.Ctx({{.Name}}); ctxLogger.GetLevel() != zerolog.Disabled {
+                logger = l.{{optionsField}}.withFields(*ctxLogger)
+            }
+        }
+        {{- end}}
+{{- end}}
+
+{{define "level"}}
+        {{- with .ErrorResult}}
+        event := logger.{{title successLevel}}
+        if {{.Name}} != nil {
+            event = logger.{{title errorLevel}}
+        }
+        {{- end}}
+{{- end}}
+
+{{define "log"}}
+        {{- template "logger" .}}
+        {{- template "level" .}}
+        {{if .ErrorResult}}event(){{else}}logger.{{title defaultLevel}}(){{end}}.
+            {{- range .Params}}{{with zerologField .}}
+                {{.}}.
+            {{- end}}{{end}}
+            Dur("took", time.Since(begin)).
+            {{- range .ValueResults}}{{with zerologField .}}
+                {{.}}.
+            {{- end}}{{end}}
+            {{- with .ErrorResult}}
+                {{if .Redacted}}{{zerologField .}}{{else}}Err({{.Name}}){{end}}.
+            {{- end}}
+            Msg(l.{{optionsField}}.prefix + "Method {{.Name}} called")
+{{- end}}
+`
+
+var slogTmpl = `
+{{define "loggerType"}}*slog.Logger{{end}}
+{{define "defaultLogger"}}slog.Default(){{end}}
+{{define "fieldsType"}}[]any{{end}}
+{{define "fieldsParam"}}...any{{end}}
+
+{{define "addFields"}}
+        o.fields = append(o.fields, fields...)
+{{- end}}
+
+{{define "withFields"}}
+    if len(o.fields) > 0 {
+        logger = logger.With(o.fields...)
+    }
+{{- end}}
+
+{{define "applyFields"}}{{end}}
+
+{{define "level"}}
+        {{- if .ContextParam}}
+        {{- with .ErrorResult}}
+        level := slog.Level{{title successLevel}}
+        if {{.Name}} != nil {
+            level = slog.Level{{title errorLevel}}
+        }
+        {{- end}}
+        {{- else}}
+        {{- with .ErrorResult}}
+        logFn := logger.{{title successLevel}}
+        if {{.Name}} != nil {
+            logFn = logger.{{title errorLevel}}
+        }
+        {{- end}}
+        {{- end}}
+{{- end}}
+
+{{define "logFn"}}
+        {{- if .ContextParam -}}
+        logger.Log({{.ContextParam.Name}}, {{if .ErrorResult}}level{{else}}slog.Level{{title defaultLevel}}{{end}},
+        {{- else -}}
+        {{if .ErrorResult}}logFn{{else}}logger.{{title defaultLevel}}{{end}}(
+        {{- end}}
+{{- end}}
+
+{{define "log"}}
+        logger := l.{{optionsField}}.currentLogger()
+        {{- template "level" .}}
+        {{template "logFn" .}} l.{{optionsField}}.prefix + "Method {{.Name}} called",
+            {{- range .Params}}{{with slogAttr .}}
+                {{.}},
+            {{- end}}{{end}}
+            slog.Duration("took", time.Since(begin)),
+            {{- range .Res}}{{with slogAttr .}}
+                {{.}},
+            {{- end}}{{end}}
+        )
+{{- end}}
+`
+
+var zapTmpl = `
+{{define "loggerType"}}*zap.Logger{{end}}
+{{define "defaultLogger"}}zap.L(){{end}}
+{{define "fieldsType"}}[]zap.Field{{end}}
+{{define "fieldsParam"}}...zap.Field{{end}}
+
+{{define "addFields"}}
+        o.fields = append(o.fields, fields...)
+{{- end}}
+
+{{define "withFields"}}
+    if len(o.fields) > 0 {
+        logger = logger.With(o.fields...)
+    }
+{{- end}}
+
+{{define "applyFields"}}{{end}}
+
+{{define "level"}}
+        {{- with .ErrorResult}}
+        logFn := logger.{{title successLevel}}
+        if {{.Name}} != nil {
+            logFn = logger.{{title errorLevel}}
+        }
+        {{- end}}
+{{- end}}
+
+{{define "log"}}
+        logger := l.{{optionsField}}.currentLogger()
+        {{- template "level" .}}
+        {{if .ErrorResult}}logFn{{else}}logger.{{title defaultLevel}}{{end}}(l.{{optionsField}}.prefix + "Method {{.Name}} called",
+            {{- range .Params}}{{with zapField .}}
+                {{.}},
+            {{- end}}{{end}}
+            zap.Duration("took", time.Since(begin)),
+            {{- range .Res}}{{with zapField .}}
+                {{.}},
+            {{- end}}{{end}}
+        )
+{{- end}}
+`
+
+var logrusTmpl = `
+{{define "loggerType"}}logrus.FieldLogger{{end}}
+{{define "defaultLogger"}}logrus.StandardLogger(){{end}}
+{{define "fieldsType"}}logrus.Fields{{end}}
+{{define "fieldsParam"}}logrus.Fields{{end}}
+
+{{define "addFields"}}
+        if o.fields == nil {
+            o.fields = logrus.Fields{}
+        }
+        for k, v := range fields {
+            o.fields[k] = v
+        }
+{{- end}}
+
+{{define "withFields"}}
+    if len(o.fields) > 0 {
+        logger = logger.WithFields(o.fields)
+    }
+{{- end}}
+
+{{define "applyFields"}}{{end}}
+
+{{define "level"}}
+        {{- with .ErrorResult}}
+        logFn := entry.{{title successLevel}}
+        if {{.Name}} != nil {
+            logFn = entry.{{title errorLevel}}
+        }
+        {{- end}}
+{{- end}}
+
+{{define "logger"}}
+        logger := l.{{optionsField}}.currentLogger()
+        {{- with .ContextParam}}
+        if ctxLogger, ok := logger.(interface{ WithContext(context.Context) *logrus.Entry }); ok {
+            logger = ctxLogger.WithContext({{.Name}})
+        }
+        {{- end}}
+{{- end}}
+
+{{define "log"}}
+        {{- template "logger" .}}
+        entry := logger.WithFields(logrus.Fields{
+            {{- range .Params}}{{if loggable .}}
+                "{{.Key}}": {{value .}},
+            {{- end}}{{end}}
+            "took": time.Since(begin),
+            {{- range .Res}}{{if loggable .}}
+                "{{.Key}}": {{value .}},
+            {{- end}}{{end}}
+        })
+        {{- template "level" .}}
+        {{if .ErrorResult}}logFn{{else}}entry.{{title defaultLevel}}{{end}}(l.{{optionsField}}.prefix + "Method {{.Name}} called")
+{{- end}}
+`
+
+var stdlibTmpl = `
+{{define "loggerType"}}*log.Logger{{end}}
+{{define "defaultLogger"}}log.Default(){{end}}
+{{define "fieldsType"}}[]interface{}{{end}}
+{{define "fieldsParam"}}...interface{}{{end}}
+
+{{define "addFields"}}
+        o.fields = append(o.fields, fields...)
+{{- end}}
+
+{{define "withFields"}}{{end}}
+
+{{define "applyFields"}}
+    for i := 0; i+1 < len(options.fields); i += 2 {
+        options.prefix += fmt.Sprintf("%v=%v ", options.fields[i], options.fields[i+1])
+    }
+{{- end}}
+
+{{define "level"}}
+        {{- with .ErrorResult}}
+        level := "{{upper successLevel}}"
+        if {{.Name}} != nil {
+            level = "{{upper errorLevel}}"
+        }
+        {{- end}}
+{{- end}}
+
+{{define "log"}}
+        logger := l.{{optionsField}}.currentLogger()
+        {{- template "level" .}}
+        logger.Printf("[%v] %vMethod {{.Name}} called{{range .Params}}{{if loggable .}} {{.Key}}=%v{{end}}{{end}} took=%v{{range .Res}}{{if loggable .}} {{.Key}}=%v{{end}}{{end}}",
+            {{if .ErrorResult}}level{{else}}"{{upper defaultLevel}}"{{end}},
+            l.{{optionsField}}.prefix,
+            {{- range .Params}}{{if loggable .}}
+                {{value .}},
+            {{- end}}{{end}}
+            time.Since(begin),
+            {{- range .Res}}{{if loggable .}}
+                {{value .}},
+            {{- end}}{{end}}
+        )
+{{- end}}
+`
+
+var gokitTmpl = `
+{{define "loggerType"}}log.Logger{{end}}
+{{define "defaultLogger"}}log.NewLogfmtLogger(os.Stderr){{end}}
+{{define "fieldsType"}}[]interface{}{{end}}
+{{define "fieldsParam"}}...interface{}{{end}}
+
+{{define "addFields"}}
+        o.fields = append(o.fields, fields...)
+{{- end}}
+
+{{define "withFields"}}
+    if len(o.fields) > 0 {
+        logger = log.With(logger, o.fields...)
+    }
+{{- end}}
+
+{{define "applyFields"}}{{end}}
+
+{{define "level"}}
+        {{- with .ErrorResult}}
+        logLevel := level.{{title successLevel}}
+        if {{.Name}} != nil {
+            logLevel = level.{{title errorLevel}}
+        }
+        {{- end}}
+{{- end}}
+
+{{define "log"}}
+        logger := l.{{optionsField}}.currentLogger()
+        {{- template "level" .}}
+        {{if .ErrorResult}}logLevel{{else}}level.{{title defaultLevel}}{{end}}(logger).Log(
+            "msg", l.{{optionsField}}.prefix + "Method {{.Name}} called",
+            {{- range .Params}}{{if loggable .}}
+                "{{.Key}}", {{value .}},
+            {{- end}}{{end}}
+            "took", time.Since(begin),
+            {{- range .Res}}{{if loggable .}}
+                "{{.Key}}", {{value .}},
+            {{- end}}{{end}}
+        )
+{{- end}}
+`
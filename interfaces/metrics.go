@@ -0,0 +1,114 @@
+package interfaces
+
+// metricsTmpl is the template of the metrics middleware. Every call is
+// counted and timed, calls returning a non-nil error are counted separately.
+// All metrics are labelled with the interface and method name.
+var metricsTmpl = `// Code generated by github.com/hanofzelbri/middleware-generato; DO NOT EDIT
+
+package {{.WrapperPackageName}}
+{{template "imports"}}
+
+{{if .Comment}}{{.Comment}}{{end -}}
+type {{.WrapperStructName}}{{.TypeParamList}} struct {
+    {{wrapperField}} {{.TypeName}}
+    {{field "calls"}} *prometheus.CounterVec
+    {{field "errors"}} *prometheus.CounterVec
+    {{field "duration"}} *prometheus.HistogramVec
+}
+
+type {{.WrapperStructName}}Options struct {
+    namespace string
+    subsystem string
+    buckets   []float64
+}
+
+// {{.MiddleWareFunctionName}}Option configures the middleware created by {{.MiddleWareFunctionName}}
+type {{.MiddleWareFunctionName}}Option func(*{{.WrapperStructName}}Options)
+
+// {{.MiddleWareFunctionName}}Namespace sets the namespace of the metric names
+func {{.MiddleWareFunctionName}}Namespace(namespace string) {{.MiddleWareFunctionName}}Option {
+    return func(o *{{.WrapperStructName}}Options) {
+        o.namespace = namespace
+    }
+}
+
+// {{.MiddleWareFunctionName}}Subsystem sets the subsystem of the metric names
+func {{.MiddleWareFunctionName}}Subsystem(subsystem string) {{.MiddleWareFunctionName}}Option {
+    return func(o *{{.WrapperStructName}}Options) {
+        o.subsystem = subsystem
+    }
+}
+
+// {{.MiddleWareFunctionName}}Buckets sets the buckets of the duration histogram in seconds, defaults to prometheus.DefBuckets
+func {{.MiddleWareFunctionName}}Buckets(buckets ...float64) {{.MiddleWareFunctionName}}Option {
+    return func(o *{{.WrapperStructName}}Options) {
+        o.buckets = buckets
+    }
+}
+
+// {{.MiddleWareFunctionName}} adds metrics for interface {{.Name}}. The metrics are registered with
+// registerer unless it is nil. Metrics already registered by another middleware are shared.
+func {{.MiddleWareFunctionName}}{{.TypeParamList}}(wrapper {{.TypeName}}, registerer prometheus.Registerer, opts ...{{.MiddleWareFunctionName}}Option) {{.TypeName}} {
+    options := {{.WrapperStructName}}Options{
+        buckets: prometheus.DefBuckets,
+    }
+    for _, opt := range opts {
+        opt(&options)
+    }
+
+    register := func(c prometheus.Collector) prometheus.Collector {
+        if registerer == nil {
+            return c
+        }
+        if err := registerer.Register(c); err != nil {
+            registered, ok := err.(prometheus.AlreadyRegisteredError)
+            if !ok {
+                panic(err)
+            }
+            return registered.ExistingCollector
+        }
+        return c
+    }
+    labels := []string{"interface", "method"}
+
+    return &{{.WrapperStructName}}{{.TypeArgList}}{
+        {{wrapperField}}: wrapper,
+        {{field "calls"}}: register(prometheus.NewCounterVec(prometheus.CounterOpts{
+            Namespace: options.namespace,
+            Subsystem: options.subsystem,
+            Name:      "method_calls_total",
+            Help:      "Total number of method calls.",
+        }, labels)).(*prometheus.CounterVec),
+        {{field "errors"}}: register(prometheus.NewCounterVec(prometheus.CounterOpts{
+            Namespace: options.namespace,
+            Subsystem: options.subsystem,
+            Name:      "method_errors_total",
+            Help:      "Total number of method calls returning an error.",
+        }, labels)).(*prometheus.CounterVec),
+        {{field "duration"}}: register(prometheus.NewHistogramVec(prometheus.HistogramOpts{
+            Namespace: options.namespace,
+            Subsystem: options.subsystem,
+            Name:      "method_duration_seconds",
+            Help:      "Duration of method calls in seconds.",
+            Buckets:   options.buckets,
+        }, labels)).(*prometheus.HistogramVec),
+    }
+}
+
+{{range $f := .Functions}}
+{{if .Comment}}{{.Comment}}{{end -}}
+func (l *{{$.WrapperStructName}}{{$.TypeArgList}}) {{.Name}}({{range .Params}}{{.Name}} {{.Type.Name}}, {{end}}) ({{range .Res}}{{.Name}} {{.Type.Name}}, {{end}}) {
+    defer func(begin time.Time) {
+        l.{{field "duration"}}.WithLabelValues("{{$.ShortName}}", "{{$f.Name}}").Observe(time.Since(begin).Seconds())
+        l.{{field "calls"}}.WithLabelValues("{{$.ShortName}}", "{{$f.Name}}").Inc()
+        {{- with .ErrorResult}}
+        if {{.Name}} != nil {
+            l.{{field "errors"}}.WithLabelValues("{{$.ShortName}}", "{{$f.Name}}").Inc()
+        }
+        {{- end}}
+    }(time.Now())
+
+    {{if .Res}}return{{end}} l.{{wrapperField}}.{{.Name}}({{range $i, $p := .Params}}{{if $i}},{{end}}{{$p.Name}}{{end}}{{if .IsVariadic}}...{{end}})
+}
+{{end}}
+`
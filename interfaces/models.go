@@ -2,64 +2,238 @@ package interfaces
 
 import (
     "go/types"
-
-    "golang.org/x/tools/go/loader"
+    "strings"
+    "time"
 )
 
-// Options represents commandline arguments
+// Options represents commandline arguments and the jobs of a project config file
 type Options struct {
-    Query                              string
-    Wrapper                            string
-    Output                             string
-    MiddlewareFunctionName             string
-    EmptyFunctionParamNamePrefix       string
-    EmptyFunctionReturnParamNamePrefix string
+    Query                              string            `json:"interface,omitempty"`
+    Queries                            []string          `json:"interfaces,omitempty"`
+    Match                              string            `json:"match,omitempty"`
+    Split                              bool              `json:"split,omitempty"`
+    Check                              bool              `json:"-"`
+    Diff                               bool              `json:"-"`
+    NoTypeCheck                        bool              `json:"noTypeCheck,omitempty"`
+    Wrapper                            string            `json:"wrapper,omitempty"`
+    Output                             string            `json:"output,omitempty"`
+    LocalPrefix                        string            `json:"localPrefix,omitempty"`
+    MiddlewareFunctionName             string            `json:"middlewareFunctionName,omitempty"`
+    EmptyFunctionParamNamePrefix       string            `json:"emptyFunctionParamNamePrefix,omitempty"`
+    EmptyFunctionReturnParamNamePrefix string            `json:"emptyFunctionReturnParamNamePrefix,omitempty"`
+    Kind                               string            `json:"kind,omitempty"`
+    Logger                             string            `json:"logger,omitempty"`
+    Level                              string            `json:"level,omitempty"`
+    SuccessLevel                       string            `json:"successLevel,omitempty"`
+    ErrorLevel                         string            `json:"errorLevel,omitempty"`
+    Redact                             []string          `json:"redact,omitempty"`
+    RedactPattern                      string            `json:"redactPattern,omitempty"`
+    NoRedactPattern                    bool              `json:"noRedactPattern,omitempty"`
+    RedactMode                         string            `json:"redactMode,omitempty"`
+    Templates                          []string          `json:"templates,omitempty"`
+    TemplateDirs                       []string          `json:"templateDirs,omitempty"`
+    Tags                               []string          `json:"tags,omitempty"`
+    GOOS                               string            `json:"goos,omitempty"`
+    GOARCH                             string            `json:"goarch,omitempty"`
+    Timeout                            string            `json:"timeout,omitempty"`
+    Timeouts                           map[string]string `json:"timeouts,omitempty"`
 }
 
 // Config represents a named type request.
 type Config struct {
     InterfaceName      string          `json:"interfaceName,omitempty"`
     PackageName        string          `json:"packageName,omitempty"`
-    Program            *loader.Program `json:"program,omitempty"`
+    Program            *Program        `json:"program,omitempty"`
     Package            *types.Package  `json:"package,omitempty"`
     Object             types.Object    `json:"object,omitempty"`
+    Type               types.Type      `json:"type,omitempty"`
+    TypeArgs           []types.Type    `json:"typeArgs,omitempty"`
     WrapperPackageName string          `json:"wrapperPackageName,omitempty"`
     WrapperStructName  string          `json:"wrapperStructName,omitempty"`
     Options            Options         `json:"options,omitempty"`
+    redactor           *redactor
+    timeouts           *timeouts
+    importer           *importer
 }
 
 // Interface represents an interface signature
 type Interface struct {
-    Name                   string   `json:"name,omitempty"`
-    Comment                string   `json:"comment,omitempty"`
-    Functions              []Func   `json:"functions,omitempty"`
-    Imports                []Import `json:"imports,omitempty"`
-    WrapperPackageName     string   `json:"wrapperPackageName,omitempty"`
-    WrapperStructName      string   `json:"wrapperStructName,omitempty"`
-    MiddleWareFunctionName string   `json:"middlewareFunctionName,omitempty"`
+    Name                   string        `json:"name,omitempty"`
+    Comment                string        `json:"comment,omitempty"`
+    TypeParams             []TypeParam   `json:"typeParams,omitempty"`
+    TypeArgs               []Type        `json:"typeArgs,omitempty"`
+    Functions              []Func        `json:"functions,omitempty"`
+    Imports                []Import      `json:"imports,omitempty"`
+    WrapperPackageName     string        `json:"wrapperPackageName,omitempty"`
+    WrapperStructName      string        `json:"wrapperStructName,omitempty"`
+    MiddleWareFunctionName string        `json:"middlewareFunctionName,omitempty"`
+    Kind                   string        `json:"kind,omitempty"`
+    Logger                 string        `json:"logger,omitempty"`
+    Level                  string        `json:"level,omitempty"`
+    SuccessLevel           string        `json:"successLevel,omitempty"`
+    ErrorLevel             string        `json:"errorLevel,omitempty"`
+    RedactMode             string        `json:"redactMode,omitempty"`
+    LocalPrefix            string        `json:"localPrefix,omitempty"`
+    // Timeout is the configured default timeout, zero if DefaultTimeout is used
+    Timeout                time.Duration `json:"timeout,omitempty"`
+}
+
+// TypeParamList returns the type parameter list of a generic interface,
+// e.g. "[K comparable, V any]", and an empty string otherwise
+func (i Interface) TypeParamList() string {
+    if len(i.TypeParams) == 0 {
+        return ""
+    }
+
+    params := make([]string, 0, len(i.TypeParams))
+    for _, p := range i.TypeParams {
+        params = append(params, p.Name+" "+p.Constraint.Name)
+    }
+
+    return "[" + strings.Join(params, ", ") + "]"
+}
+
+// TypeArgList returns the type parameters of a generic interface as type
+// arguments, e.g. "[K, V]", and an empty string otherwise
+func (i Interface) TypeArgList() string {
+    if len(i.TypeParams) == 0 {
+        return ""
+    }
+
+    args := make([]string, 0, len(i.TypeParams))
+    for _, p := range i.TypeParams {
+        args = append(args, p.Name)
+    }
+
+    return "[" + strings.Join(args, ", ") + "]"
+}
+
+// ShortName returns the name of the interface without package qualifier
+func (i Interface) ShortName() string {
+    if idx := strings.LastIndex(i.Name, "."); idx != -1 {
+        return i.Name[idx+1:]
+    }
+
+    return i.Name
+}
+
+// TypeName returns the wrapped interface type, e.g. "cache.Store[K, V]" for a
+// generic interface or "cache.Store[string, *model.User]" for an instantiation
+func (i Interface) TypeName() string {
+    if len(i.TypeArgs) == 0 {
+        return i.Name + i.TypeArgList()
+    }
+
+    args := make([]string, 0, len(i.TypeArgs))
+    for _, a := range i.TypeArgs {
+        args = append(args, a.Name)
+    }
+
+    return i.Name + "[" + strings.Join(args, ", ") + "]"
+}
+
+// TypeParam represents a type parameter of a generic interface
+type TypeParam struct {
+    Name       string `json:"name,omitempty"`
+    Constraint Type   `json:"constraint,omitempty"`
 }
 
 // Func represents a function signature
 type Func struct {
-    Name       string  `json:"name,omitempty"`
-    Params     []Param `json:"params,omitempty"`
-    Res        []Param `json:"res,omitempty"`
-    Comment    string  `json:"comment,omitempty"`
-    IsVariadic bool    `json:"isVariadic,omitempty"`
+    Name       string        `json:"name,omitempty"`
+    Params     []Param       `json:"params,omitempty"`
+    Res        []Param       `json:"res,omitempty"`
+    Comment    string        `json:"comment,omitempty"`
+    IsVariadic bool          `json:"isVariadic,omitempty"`
+    // NoRetry is set for methods annotated with middleware:noretry
+    NoRetry    bool          `json:"noRetry,omitempty"`
+    // Traced lists the parameters named by a middleware:trace annotation,
+    // all parameters are traced if it is nil
+    Traced     []string      `json:"traced,omitempty"`
+    // Timeout is the configured timeout of the method, zero if the default is used
+    Timeout    time.Duration `json:"timeout,omitempty"`
+}
+
+// ErrorResult returns the last result if it is an error, nil otherwise
+func (f Func) ErrorResult() *Param {
+    if len(f.Res) == 0 || f.Res[len(f.Res)-1].Type.Kind != KindError {
+        return nil
+    }
+
+    return &f.Res[len(f.Res)-1]
+}
+
+// ContextParam returns the first context.Context parameter, nil if there is none
+func (f Func) ContextParam() *Param {
+    for i := range f.Params {
+        if f.Params[i].Type.Kind == KindContext {
+            return &f.Params[i]
+        }
+    }
+
+    return nil
+}
+
+// ValueResults returns all results except the one returned by ErrorResult
+func (f Func) ValueResults() []Param {
+    if f.ErrorResult() == nil {
+        return f.Res
+    }
+
+    return f.Res[:len(f.Res)-1]
 }
 
 // Param represents a parameter in a function or method signature
 type Param struct {
-    Name string `json:"name,omitempty"`
-    Type Type   `json:"type,omitempty"`
+    Name         string `json:"name,omitempty"`
+    // OriginalName is the declared name if Name was renamed to avoid a collision
+    OriginalName string `json:"originalName,omitempty"`
+    Type         Type   `json:"type,omitempty"`
+    Redacted     bool   `json:"redacted,omitempty"`
+}
+
+// Key returns the name the value of the parameter is logged and recorded
+// with, which is the declared name even if the parameter was renamed
+func (p Param) Key() string {
+    if p.OriginalName != "" {
+        return p.OriginalName
+    }
+
+    return p.Name
 }
 
 // Type represents a simple representation of a single parameter type
 type Type struct {
     Name    string   `json:"name,omitempty"`
     Imports []Import `json:"imports,omitempty"`
+    // Kind classifies the type to select a matching log field
+    Kind    Kind     `json:"kind,omitempty"`
+    // Basic is the type a value is converted to before it is logged,
+    // e.g. string for a named string type
+    Basic   string   `json:"basic,omitempty"`
 }
 
+// Kind classifies a type by the way its values can be logged
+type Kind string
+
+// Kinds of types which are logged with a type specific field instead of reflection
+const (
+    KindOther    Kind = ""
+    KindString   Kind = "string"
+    KindBool     Kind = "bool"
+    KindInt      Kind = "int"
+    KindUint     Kind = "uint"
+    KindFloat    Kind = "float"
+    KindError    Kind = "error"
+    KindStringer Kind = "stringer"
+    KindTime     Kind = "time"
+    KindDuration Kind = "duration"
+    KindBytes    Kind = "bytes"
+    KindFunc     Kind = "func"
+    KindChan     Kind = "chan"
+    KindContext  Kind = "context"
+)
+
 // Import defines imported package
 type Import struct {
     Package string `json:"package,omitempty"`
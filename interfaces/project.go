@@ -0,0 +1,153 @@
+package interfaces
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultProjectFile is the project config file used if none is provided
+const DefaultProjectFile = ".middleware-generator.yaml"
+
+// Job is a single generator run of a project config file
+type Job struct {
+	Name string `json:"name,omitempty"`
+	Options
+}
+
+// project is the layout of a project config file
+type project struct {
+	Defaults json.RawMessage   `json:"defaults,omitempty"`
+	Jobs     []json.RawMessage `json:"jobs"`
+}
+
+// ReadProject reads the jobs of a YAML or JSON project config file. Every job
+// starts with defaults, overridden by the defaults section of the file and
+// then by its own fields. Relative paths are resolved against the directory
+// of the file.
+func ReadProject(path string, defaults Options) ([]Job, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err = yamlToJSON(content)
+	if err != nil {
+		return nil, fmt.Errorf("Parsing project %q: %v", path, err)
+	}
+
+	var p project
+	if err := decodeStrict(content, &p); err != nil {
+		return nil, fmt.Errorf("Parsing project %q: %v", path, err)
+	}
+	if len(p.Jobs) == 0 {
+		return nil, fmt.Errorf("Project %q contains no jobs", path)
+	}
+
+	defaults = defaults.clone()
+	if len(p.Defaults) > 0 {
+		if err := decodeStrict(p.Defaults, &defaults); err != nil {
+			return nil, fmt.Errorf("Parsing defaults of project %q: %v", path, err)
+		}
+		if err := overrideQueries(p.Defaults, &defaults); err != nil {
+			return nil, fmt.Errorf("Parsing defaults of project %q: %v", path, err)
+		}
+	}
+
+	dir := filepath.Dir(path)
+	jobs := make([]Job, 0, len(p.Jobs))
+	for i, content := range p.Jobs {
+		job := Job{Options: defaults.clone()}
+		if err := decodeStrict(content, &job); err != nil {
+			return nil, fmt.Errorf("Parsing job %d of project %q: %v", i+1, path, err)
+		}
+		if err := overrideQueries(content, &job.Options); err != nil {
+			return nil, fmt.Errorf("Parsing job %d of project %q: %v", i+1, path, err)
+		}
+
+		job.Options = resolvePaths(job.Options, dir)
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+// yamlToJSON converts YAML to JSON. YAML is a superset of JSON, converting it
+// allows strict decoding by json tags.
+func yamlToJSON(content []byte) ([]byte, error) {
+	var raw interface{}
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(raw)
+}
+
+func decodeStrict(content []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(content))
+	dec.DisallowUnknownFields()
+
+	return dec.Decode(v)
+}
+
+// overrideQueries drops the inherited queries of options if content sets a
+// single interface, which would be ignored in favour of them otherwise
+func overrideQueries(content []byte, options *Options) error {
+	var set struct {
+		Query   *string         `json:"interface"`
+		Queries json.RawMessage `json:"interfaces"`
+	}
+	if err := json.Unmarshal(content, &set); err != nil {
+		return err
+	}
+	if set.Query != nil && set.Queries != nil {
+		return fmt.Errorf("interface and interfaces are mutually exclusive")
+	}
+	if set.Query != nil {
+		options.Queries = nil
+	}
+
+	return nil
+}
+
+// clone copies options so decoding into the copy doesn't change the slices and maps of o
+func (o Options) clone() Options {
+	for _, s := range []*[]string{&o.Queries, &o.Redact, &o.Templates, &o.TemplateDirs, &o.Tags} {
+		*s = append([]string(nil), *s...)
+	}
+	if o.Timeouts != nil {
+		timeouts := make(map[string]string, len(o.Timeouts))
+		for method, timeout := range o.Timeouts {
+			timeouts[method] = timeout
+		}
+		o.Timeouts = timeouts
+	}
+
+	return o
+}
+
+func resolvePaths(options Options, dir string) Options {
+	resolve := func(path string) string {
+		if path == "" || filepath.IsAbs(path) {
+			return path
+		}
+		return filepath.Join(dir, path)
+	}
+
+	options.Output = resolve(options.Output)
+	for i, templateDir := range options.TemplateDirs {
+		options.TemplateDirs[i] = resolve(templateDir)
+	}
+	// Templates which don't exist next to the project file are looked up in TemplateDirs
+	for i, file := range options.Templates {
+		if _, err := os.Stat(resolve(file)); err == nil {
+			options.Templates[i] = resolve(file)
+		}
+	}
+
+	return options
+}
@@ -0,0 +1,130 @@
+package interfaces
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadProject(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    []Job
+		wantErr bool
+	}{
+		{
+			name: "yaml with defaults",
+			file: DefaultProjectFile,
+			content: `
+defaults:
+  logger: slog
+  tags: [integration]
+jobs:
+  - name: reader
+    interface: io.Reader
+    output: reader_gen.go
+  - interfaces: [io.Writer, io.Closer]
+    wrapper: middleware.wrapper
+    logger: zap
+    tags: [other]
+`,
+			want: []Job{
+				{Name: "reader", Options: Options{Query: "io.Reader", Output: "reader_gen.go", MiddlewareFunctionName: "WithMiddleware", Logger: LoggerSlog, Tags: []string{"integration"}}},
+				{Options: Options{Queries: []string{"io.Writer", "io.Closer"}, Wrapper: "middleware.wrapper", MiddlewareFunctionName: "WithMiddleware", Logger: LoggerZap, Tags: []string{"other"}}},
+			},
+		},
+		{
+			name:    "json",
+			file:    "middleware.json",
+			content: `{"jobs": [{"interface": "io.Reader", "kind": "logging", "emptyFunctionParamNamePrefix": "p"}]}`,
+			want: []Job{
+				{Options: Options{Query: "io.Reader", Kind: MiddlewareLogging, MiddlewareFunctionName: "WithMiddleware", EmptyFunctionParamNamePrefix: "p", Logger: LoggerZerolog}},
+			},
+		},
+		{
+			name: "job interface overrides inherited interfaces",
+			file: DefaultProjectFile,
+			content: `
+defaults:
+  interfaces: [io.Writer, io.Closer]
+jobs:
+  - interface: io.Reader
+  - output: writer_gen.go
+`,
+			want: []Job{
+				{Options: Options{Query: "io.Reader", MiddlewareFunctionName: "WithMiddleware", Logger: LoggerZerolog}},
+				{Options: Options{Queries: []string{"io.Writer", "io.Closer"}, Output: "writer_gen.go", MiddlewareFunctionName: "WithMiddleware", Logger: LoggerZerolog}},
+			},
+		},
+		{
+			name:    "interface and interfaces",
+			file:    DefaultProjectFile,
+			content: "jobs:\n  - interface: io.Reader\n    interfaces: [io.Writer]\n",
+			wantErr: true,
+		},
+		{
+			name:    "unknown field",
+			file:    DefaultProjectFile,
+			content: "jobs:\n  - interfac: io.Reader\n",
+			wantErr: true,
+		},
+		{
+			name:    "no jobs",
+			file:    DefaultProjectFile,
+			content: "defaults:\n  logger: slog\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid yaml",
+			file:    DefaultProjectFile,
+			content: "jobs: [",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, tt.file)
+			assert.NoError(t, os.WriteFile(path, []byte(tt.content), 0644))
+
+			got, err := ReadProject(path, Options{MiddlewareFunctionName: "WithMiddleware", Logger: LoggerZerolog})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			for i := range tt.want {
+				if tt.want[i].Output != "" {
+					tt.want[i].Output = filepath.Join(dir, tt.want[i].Output)
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBuildJobs(t *testing.T) {
+	jobs := []Options{
+		{Query: "github.com/hanofzelbri/middleware-generator/interfaces.EmptyInterface", MiddlewareFunctionName: "WithWrapper"},
+		{Query: "github.com/hanofzelbri/middleware-generator/interfaces.NotExisting", MiddlewareFunctionName: "WithWrapper"},
+		{Query: "io.Reader", MiddlewareFunctionName: "WithWrapper", Level: "trace"},
+		{Queries: []string{"io.Reader", "io.Writer"}, MiddlewareFunctionName: "WithWrapper"},
+	}
+
+	got := BuildJobs(jobs)
+	if !assert.Len(t, got, len(jobs)) {
+		return
+	}
+
+	assert.NoError(t, got[0].Err)
+	assert.Len(t, got[0].Interfaces, 1)
+	assert.Error(t, got[1].Err)
+	assert.Error(t, got[2].Err)
+	assert.NoError(t, got[3].Err)
+	assert.Len(t, got[3].Interfaces, 2)
+}
@@ -0,0 +1,173 @@
+package interfaces
+
+import (
+	"fmt"
+	"go/types"
+	"regexp"
+	"strings"
+)
+
+// Redaction modes defining what is logged in place of a redacted value
+const (
+	RedactModePlaceholder = ""
+	RedactModeLength      = "length"
+	RedactModeHash        = "hash"
+)
+
+// DefaultRedactPattern matches parameter names which are redacted if no
+// other pattern is configured and the pattern isn't disabled
+const DefaultRedactPattern = `(?i)passw(or)?d|token|secret`
+
+// redactAnnotation marks parameters to redact in a method doc comment,
+// e.g. "// middleware:redact user, password"
+var redactAnnotation = regexp.MustCompile(`middleware:redact\s+([\w\s,]+)`)
+
+type redactor struct {
+	names   map[string]bool
+	pattern *regexp.Regexp
+}
+
+func newRedactor(options Options) (*redactor, error) {
+	switch options.RedactMode {
+	case RedactModePlaceholder, RedactModeLength, RedactModeHash:
+	default:
+		return nil, fmt.Errorf("Unknown redact mode %q, supported modes are: %v, %v", options.RedactMode, RedactModeLength, RedactModeHash)
+	}
+
+	r := &redactor{names: map[string]bool{}}
+	for _, name := range options.Redact {
+		r.names[name] = true
+	}
+
+	if !options.NoRedactPattern {
+		expr := options.RedactPattern
+		if expr == "" {
+			expr = DefaultRedactPattern
+		}
+
+		pattern, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid redact pattern %q: %v", expr, err)
+		}
+		r.pattern = pattern
+	}
+
+	return r, nil
+}
+
+// redactFunc marks all params and results of f with signature sig which are
+// configured by name or type, match the pattern or are annotated in the doc
+// comment of f
+func (r *redactor) redactFunc(f *Func, sig *types.Signature) {
+	annotated := redactAnnotations(f.Comment)
+
+	for ti, tuple := range []*types.Tuple{sig.Params(), sig.Results()} {
+		params := [][]Param{f.Params, f.Res}[ti]
+		for i := range params {
+			p := &params[i]
+			if p.Type.Kind == KindContext {
+				continue
+			}
+
+			typ := tuple.At(i).Type()
+			// Variadic params are configured by their element type like
+			// they are declared
+			if ti == 0 && sig.Variadic() && i == tuple.Len()-1 {
+				typ = typ.(*types.Slice).Elem()
+			}
+
+			p.Redacted = annotated[p.Name] || r.names[p.Name] || r.redactedType(typ) ||
+				(r.pattern != nil && r.pattern.MatchString(p.Name))
+		}
+	}
+}
+
+// redactedType reports whether typ is configured, qualified by the name or
+// the path of its package regardless of the import name, e.g. uuid.UUID or
+// github.com/google/uuid.UUID
+func (r *redactor) redactedType(typ types.Type) bool {
+	byName := types.TypeString(typ, func(pkg *types.Package) string { return pkg.Name() })
+	byPath := types.TypeString(typ, func(pkg *types.Package) string { return pkg.Path() })
+
+	return r.names[byName] || r.names[byPath]
+}
+
+func redactAnnotations(comment string) map[string]bool {
+	return annotatedNames(redactAnnotation, comment)
+}
+
+// annotatedNames returns the names listed by the annotations matching
+// annotation in comment
+func annotatedNames(annotation *regexp.Regexp, comment string) map[string]bool {
+	names := map[string]bool{}
+
+	for _, line := range strings.Split(comment, "\n") {
+		m := annotation.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+
+		for _, name := range strings.FieldsFunc(m[1], func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+			names[name] = true
+		}
+	}
+
+	return names
+}
+
+// methodAnnotation matches a line of a method doc comment which only holds
+// an annotation of the middleware generator
+var methodAnnotation = regexp.MustCompile(`^\s*(//)?\s*middleware:(redact|noretry|trace)\b[\w\s,]*$`)
+
+// stripAnnotations removes all annotation lines from comment
+func stripAnnotations(comment string) string {
+	lines := []string{}
+
+	for _, line := range strings.SplitAfter(comment, "\n") {
+		if !methodAnnotation.MatchString(strings.TrimSuffix(line, "\n")) {
+			lines = append(lines, line)
+		}
+	}
+
+	return strings.Join(lines, "")
+}
+
+// redactImports returns the imports required by the redacted values of inter
+func redactImports(inter *Interface) []Import {
+	if inter.RedactMode == RedactModePlaceholder {
+		return nil
+	}
+
+	for _, f := range inter.Functions {
+		for _, params := range [][]Param{f.Params, f.Res} {
+			for _, p := range params {
+				if !p.Redacted {
+					continue
+				}
+
+				if inter.RedactMode == RedactModeHash {
+					return []Import{{Package: "sha256", Path: "crypto/sha256"}, {Package: "fmt", Path: "fmt"}}
+				}
+				return []Import{{Package: "fmt", Path: "fmt"}}
+			}
+		}
+	}
+
+	return nil
+}
+
+// redactedValue returns the string expression logged in place of p
+func redactedValue(p Param, mode string) string {
+	switch mode {
+	case RedactModeLength:
+		length := fmt.Sprintf("len(fmt.Sprint(%v))", p.Name)
+		if p.Type.Kind == KindString || p.Type.Kind == KindBytes {
+			length = fmt.Sprintf("len(%v)", p.Name)
+		}
+		return fmt.Sprintf(`fmt.Sprintf("[REDACTED len=%%d]", %v)`, length)
+	case RedactModeHash:
+		return fmt.Sprintf(`fmt.Sprintf("[REDACTED sha256=%%.4x]", sha256.Sum256([]byte(fmt.Sprint(%v))))`, p.Name)
+	}
+
+	return `"[REDACTED]"`
+}
@@ -0,0 +1,140 @@
+package interfaces
+
+import "regexp"
+
+// noRetryAnnotation marks methods which must not be retried in a method doc
+// comment, e.g. "// middleware:noretry" for non-idempotent methods
+var noRetryAnnotation = regexp.MustCompile(`middleware:noretry\b`)
+
+// retryable reports whether calls of f are retried by the retry middleware
+func retryable(f Func) bool {
+	return f.ErrorResult() != nil && !f.NoRetry
+}
+
+// retryTmpl is the template of the retry middleware. Calls of methods whose
+// last result is an error are repeated with exponential backoff and jitter
+// until they succeed, the error isn't retryable or the maximum number of
+// attempts is reached. Waiting is aborted when the context parameter is done.
+var retryTmpl = `// Code generated by github.com/hanofzelbri/middleware-generato; DO NOT EDIT
+
+package {{.WrapperPackageName}}
+{{template "imports"}}
+
+{{if .Comment}}{{.Comment}}{{end -}}
+type {{.WrapperStructName}}{{.TypeParamList}} struct {
+    {{wrapperField}} {{.TypeName}}
+    {{optionsField}} {{.WrapperStructName}}Options
+}
+
+type {{.WrapperStructName}}Options struct {
+    maxAttempts    int
+    initialBackoff time.Duration
+    maxBackoff     time.Duration
+    multiplier     float64
+    jitter         float64
+    retryable      func(error) bool
+}
+
+// {{.MiddleWareFunctionName}}Option configures the middleware created by {{.MiddleWareFunctionName}}
+type {{.MiddleWareFunctionName}}Option func(*{{.WrapperStructName}}Options)
+
+// {{.MiddleWareFunctionName}}MaxAttempts sets the maximum number of calls including the first one, defaults to 3
+func {{.MiddleWareFunctionName}}MaxAttempts(maxAttempts int) {{.MiddleWareFunctionName}}Option {
+    return func(o *{{.WrapperStructName}}Options) {
+        o.maxAttempts = maxAttempts
+    }
+}
+
+// {{.MiddleWareFunctionName}}Backoff sets the wait before the first retry, which is multiplied by
+// multiplier for every further retry up to maxBackoff, defaults to 100ms, 10s and 2
+func {{.MiddleWareFunctionName}}Backoff(initialBackoff time.Duration, maxBackoff time.Duration, multiplier float64) {{.MiddleWareFunctionName}}Option {
+    return func(o *{{.WrapperStructName}}Options) {
+        o.initialBackoff = initialBackoff
+        o.maxBackoff = maxBackoff
+        o.multiplier = multiplier
+    }
+}
+
+// {{.MiddleWareFunctionName}}Jitter sets the fraction by which a backoff is randomly increased or decreased, defaults to 0.2
+func {{.MiddleWareFunctionName}}Jitter(jitter float64) {{.MiddleWareFunctionName}}Option {
+    return func(o *{{.WrapperStructName}}Options) {
+        o.jitter = jitter
+    }
+}
+
+// {{.MiddleWareFunctionName}}Retryable sets the predicate deciding whether a call returning err is retried,
+// defaults to retrying all errors except context.Canceled and context.DeadlineExceeded
+func {{.MiddleWareFunctionName}}Retryable(retryable func(err error) bool) {{.MiddleWareFunctionName}}Option {
+    return func(o *{{.WrapperStructName}}Options) {
+        o.retryable = retryable
+    }
+}
+
+// {{.MiddleWareFunctionName}} adds retries for interface {{.Name}}
+func {{.MiddleWareFunctionName}}{{.TypeParamList}}(wrapper {{.TypeName}}, opts ...{{.MiddleWareFunctionName}}Option) {{.TypeName}} {
+    options := {{.WrapperStructName}}Options{
+        maxAttempts:    3,
+        initialBackoff: 100 * time.Millisecond,
+        maxBackoff:     10 * time.Second,
+        multiplier:     2,
+        jitter:         0.2,
+        retryable: func(err error) bool {
+            return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+        },
+    }
+    for _, opt := range opts {
+        opt(&options)
+    }
+
+    return &{{.WrapperStructName}}{{.TypeArgList}}{
+        {{wrapperField}}: wrapper,
+        {{optionsField}}: options,
+    }
+}
+
+// wait blocks for the backoff after the failed attempt. It returns false
+// without waiting for the backoff if ctx is done before.
+func (o *{{.WrapperStructName}}Options) wait(ctx context.Context, attempt int) bool {
+    backoff := float64(o.initialBackoff) * math.Pow(o.multiplier, float64(attempt-1))
+    if backoff > float64(o.maxBackoff) {
+        backoff = float64(o.maxBackoff)
+    }
+    backoff += backoff * o.jitter * (2*rand.Float64() - 1)
+
+    timer := time.NewTimer(time.Duration(backoff))
+    defer timer.Stop()
+
+    if ctx == nil {
+        <-timer.C
+        return true
+    }
+
+    select {
+    case <-ctx.Done():
+        return false
+    case <-timer.C:
+        return true
+    }
+}
+
+{{range .Functions}}
+{{if .Comment}}{{.Comment}}{{end -}}
+func (l *{{$.WrapperStructName}}{{$.TypeArgList}}) {{.Name}}({{range .Params}}{{.Name}} {{.Type.Name}}, {{end}}) ({{range .Res}}{{.Name}} {{.Type.Name}}, {{end}}) {
+    {{- if retryable .}}
+    for attempt := 1; ; attempt++ {
+        {{range $i, $r := .Res}}{{if $i}}, {{end}}{{$r.Name}}{{end}} = l.{{wrapperField}}.{{.Name}}({{range $i, $p := .Params}}{{if $i}},{{end}}{{$p.Name}}{{end}}{{if .IsVariadic}}...{{end}})
+        {{- with .ErrorResult}}
+        if {{.Name}} == nil || attempt >= l.{{optionsField}}.maxAttempts || !l.{{optionsField}}.retryable({{.Name}}) {
+            return
+        }
+        {{- end}}
+        if !l.{{optionsField}}.wait({{with .ContextParam}}{{.Name}}{{else}}context.Background(){{end}}, attempt) {
+            return
+        }
+    }
+    {{- else}}
+    {{if .Res}}return{{end}} l.{{wrapperField}}.{{.Name}}({{range $i, $p := .Params}}{{if $i}},{{end}}{{$p.Name}}{{end}}{{if .IsVariadic}}...{{end}})
+    {{- end}}
+}
+{{end}}
+`
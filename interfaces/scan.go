@@ -0,0 +1,221 @@
+package interfaces
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// generateMarker requests a middleware in the doc comment of an interface,
+// e.g. "//middleware:generate logging output=logging_gen.go"
+var generateMarker = regexp.MustCompile(`^//\s*middleware:generate\b(.*)$`)
+
+// Scan looks up all interfaces of the packages matched by patterns which are
+// annotated with a generate marker and builds their middlewares. The packages
+// are loaded once and shared with the jobs. Every job starts with defaults
+// overridden by the fields of its marker, relative outputs are resolved
+// against the directory of the interface declaration.
+func Scan(defaults Options, patterns ...string) ([]Job, []JobResult, error) {
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	program, err := loadProgram(defaults, patterns...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	jobs := []Job{}
+	for _, path := range program.roots {
+		pkgJobs, err := scanPackage(program, defaults, path)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		jobs = append(jobs, pkgJobs...)
+	}
+
+	// Like for multiple interfaces of a single job the interface name is
+	// appended to default function names of jobs writing to the same package,
+	// and the kind if the interface has several markers
+	dirs := map[string]int{}
+	markers := map[string]int{}
+	for _, job := range jobs {
+		dirs[filepath.Dir(job.Output)]++
+		markers[job.Query]++
+	}
+	for i, job := range jobs {
+		if job.MiddlewareFunctionName != defaults.MiddlewareFunctionName {
+			continue
+		}
+		if dirs[filepath.Dir(job.Output)] > 1 {
+			jobs[i].MiddlewareFunctionName += job.Query[strings.LastIndex(job.Query, ".")+1:]
+		}
+		if markers[job.Query] > 1 {
+			jobs[i].MiddlewareFunctionName += kindSuffix(job.Kind)
+		}
+	}
+
+	outputs := map[string]string{}
+	for _, job := range jobs {
+		if name, ok := outputs[job.Output]; ok {
+			return nil, nil, fmt.Errorf("Generate markers of %v and %v write to the same output %q", name, job.Name, job.Output)
+		}
+		outputs[job.Output] = job.Name
+	}
+
+	jobOptions := make([]Options, 0, len(jobs))
+	for _, job := range jobs {
+		jobOptions = append(jobOptions, job.Options)
+	}
+
+	results := buildJobs(jobOptions, func(options Options, patterns ...string) (*Program, error) {
+		if loadKey(options) == loadKey(defaults) {
+			return program, nil
+		}
+		return loadProgram(options, patterns...)
+	})
+
+	return jobs, results, nil
+}
+
+func scanPackage(program *Program, defaults Options, path string) ([]Job, error) {
+	pkg := program.Package(path)
+	if pkg == nil || pkg.Types == nil {
+		return nil, nil
+	}
+
+	jobs := []Job{}
+	for _, f := range pkg.Syntax {
+		filename := program.Fset.Position(f.Pos()).Filename
+		if strings.HasSuffix(filename, "_test.go") {
+			continue
+		}
+
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range gen.Specs {
+				name := spec.(*ast.TypeSpec).Name
+				markers := generateMarkers(commentText(program, name.Pos()))
+				if len(markers) == 0 {
+					continue
+				}
+
+				obj := pkg.Types.Scope().Lookup(name.Name)
+				if obj == nil {
+					continue
+				}
+				if _, ok := obj.Type().Underlying().(*types.Interface); !ok {
+					return nil, fmt.Errorf("%v: generate marker on %q which is not an interface", program.Fset.Position(name.Pos()), name.Name)
+				}
+
+				for _, marker := range markers {
+					job, err := markerJob(marker, defaults, pkg.Types, name.Name, filepath.Dir(filename), len(markers) > 1)
+					if err != nil {
+						return nil, fmt.Errorf("%v: %v", program.Fset.Position(name.Pos()), err)
+					}
+
+					jobs = append(jobs, job)
+				}
+			}
+		}
+	}
+
+	return jobs, nil
+}
+
+// generateMarkers returns the arguments of all generate markers in comment
+func generateMarkers(comment string) []string {
+	markers := []string{}
+
+	for _, line := range strings.Split(comment, "\n") {
+		if m := generateMarker.FindStringSubmatch(line); m != nil {
+			markers = append(markers, strings.TrimSpace(m[1]))
+		}
+	}
+
+	return markers
+}
+
+// kindSuffix returns the suffix distinguishing the names generated for
+// several markers of an interface
+func kindSuffix(kind string) string {
+	return title(defaultString(kind, MiddlewareLogging))
+}
+
+// stripMarkers removes all generate markers and go:generate directives from
+// comment, they must not be copied into the generated code
+func stripMarkers(comment string) string {
+	lines := []string{}
+
+	for _, line := range strings.SplitAfter(comment, "\n") {
+		if !generateMarker.MatchString(strings.TrimSuffix(line, "\n")) && !strings.HasPrefix(line, "//go:generate") {
+			lines = append(lines, line)
+		}
+	}
+
+	return strings.Join(lines, "")
+}
+
+// markerJob creates the job of a marker like "logging output=logging_gen.go".
+// The optional first argument is the middleware kind, all others are fields
+// of a project job with YAML values, e.g. "redact=[user,pin]". The kind is
+// appended to the default wrapper and output of one of multiple markers of
+// an interface.
+func markerJob(marker string, defaults Options, pkg *types.Package, interfaceName string, dir string, multiple bool) (Job, error) {
+	job := Job{Name: pkg.Path() + "." + interfaceName, Options: defaults.clone()}
+	job.Queries = nil
+	job.Query = job.Name
+	job.Wrapper = ""
+	job.Output = ""
+
+	args := strings.Fields(marker)
+	if len(args) > 0 && !strings.Contains(args[0], "=") {
+		job.Kind = args[0]
+		args = args[1:]
+	}
+
+	fields := []string{}
+	for _, arg := range args {
+		i := strings.Index(arg, "=")
+		if i <= 0 {
+			return job, fmt.Errorf("Invalid generate marker argument %q, expected key=value", arg)
+		}
+		fields = append(fields, arg[:i]+": "+arg[i+1:])
+	}
+
+	content, err := yamlToJSON([]byte(strings.Join(fields, "\n")))
+	if err != nil {
+		return job, fmt.Errorf("Invalid generate marker %q: %v", marker, err)
+	}
+	if string(content) != "null" {
+		if err := decodeStrict(content, &job.Options); err != nil {
+			return job, fmt.Errorf("Invalid generate marker %q: %v", marker, err)
+		}
+	}
+
+	suffix := ""
+	if multiple {
+		suffix = kindSuffix(job.Kind)
+	}
+	if job.Wrapper == "" {
+		job.Wrapper = wrapperStructName("", interfaceName) + suffix
+	}
+	if !strings.Contains(job.Wrapper, ".") {
+		job.Wrapper = pkg.Name() + "." + job.Wrapper
+	}
+	if job.Output == "" {
+		job.Output = FileName(&Interface{Name: interfaceName + suffix})
+	}
+	job.Options = resolvePaths(job.Options, dir)
+
+	return job, nil
+}
@@ -0,0 +1,113 @@
+package interfaces
+
+import (
+	"go/types"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScan(t *testing.T) {
+	defaults := Options{
+		MiddlewareFunctionName:             "WithMiddleware",
+		EmptyFunctionParamNamePrefix:       "param",
+		EmptyFunctionReturnParamNamePrefix: "ret",
+		Logger:                             LoggerZerolog,
+	}
+
+	jobs, results, err := Scan(defaults, "./testdata/scan")
+	if !assert.NoError(t, err) || !assert.Len(t, jobs, 2) || !assert.Len(t, results, 2) {
+		return
+	}
+
+	dir, err := filepath.Abs("testdata/scan")
+	assert.NoError(t, err)
+
+	pkg := "github.com/hanofzelbri/middleware-generator/interfaces/testdata/scan"
+	assert.Equal(t, pkg+".Store", jobs[0].Name)
+	assert.Equal(t, filepath.Join(dir, "store_gen.go"), jobs[0].Output)
+	assert.Equal(t, "scan.store", jobs[0].Wrapper)
+	assert.Equal(t, "WithMiddlewareStore", jobs[0].MiddlewareFunctionName)
+	assert.Equal(t, LoggerSlog, jobs[0].Logger)
+	assert.Equal(t, MiddlewareLogging, jobs[0].Kind)
+
+	assert.Equal(t, pkg+".Cache", jobs[1].Name)
+	assert.Equal(t, filepath.Join(dir, "cache_middleware.go"), jobs[1].Output)
+	assert.Equal(t, "scan.loggingCache", jobs[1].Wrapper)
+	assert.Equal(t, "WithMiddlewareCache", jobs[1].MiddlewareFunctionName)
+	assert.Equal(t, []string{"key"}, jobs[1].Redact)
+	assert.Equal(t, LoggerZerolog, jobs[1].Logger)
+
+	for _, result := range results {
+		if assert.NoError(t, result.Err) && assert.Len(t, result.Interfaces, 1) {
+			assert.NotContains(t, result.Interfaces[0].Comment, "middleware:generate")
+		}
+	}
+	assert.Equal(t, "// Store persists values.\n//\n", results[0].Interfaces[0].Comment)
+	assert.Equal(t, "// Cache caches values.\n", results[1].Interfaces[0].Comment)
+}
+
+func TestScanMultipleMarkers(t *testing.T) {
+	defaults := Options{
+		MiddlewareFunctionName:             "WithMiddleware",
+		EmptyFunctionParamNamePrefix:       "param",
+		EmptyFunctionReturnParamNamePrefix: "ret",
+		Kind:                               MiddlewareLogging,
+	}
+
+	jobs, results, err := Scan(defaults, "./testdata/scan/multiple")
+	if !assert.NoError(t, err) || !assert.Len(t, jobs, 3) || !assert.Len(t, results, 3) {
+		return
+	}
+
+	dir, err := filepath.Abs("testdata/scan/multiple")
+	assert.NoError(t, err)
+
+	assert.Equal(t, filepath.Join(dir, "store_retry_middleware.go"), jobs[0].Output)
+	assert.Equal(t, "multiple.storeRetry", jobs[0].Wrapper)
+	assert.Equal(t, "WithMiddlewareStoreRetry", jobs[0].MiddlewareFunctionName)
+	assert.Equal(t, filepath.Join(dir, "store_timeout.go"), jobs[1].Output)
+	assert.Equal(t, "multiple.storeTimeout", jobs[1].Wrapper)
+	assert.Equal(t, "WithMiddlewareStoreTimeout", jobs[1].MiddlewareFunctionName)
+	assert.Equal(t, filepath.Join(dir, "store_circuitbreaker_middleware.go"), jobs[2].Output)
+
+	files := map[string][]byte{}
+	for i, result := range results {
+		if !assert.NoError(t, result.Err) || !assert.Len(t, result.Interfaces, 1) {
+			return
+		}
+
+		file, err := Render(result.Interfaces[0], jobs[i].Options)
+		if !assert.NoError(t, err) {
+			return
+		}
+		files[jobs[i].Output] = file
+	}
+	assert.NoError(t, TypeCheck(defaults, files))
+}
+
+func TestScanDuplicateOutput(t *testing.T) {
+	_, _, err := Scan(Options{MiddlewareFunctionName: "WithMiddleware"}, "./testdata/scan/duplicate")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "write to the same output")
+	}
+}
+
+func TestGenerateMarkers(t *testing.T) {
+	comment := "// Store persists values.\n//middleware:generate logging output=a.go\n// middleware:generate\n//middleware:generated\n//go:generate middleware-generator\n"
+
+	assert.Equal(t, []string{"logging output=a.go", ""}, generateMarkers(comment))
+	assert.Equal(t, "// Store persists values.\n//middleware:generated\n", stripMarkers(comment))
+}
+
+func TestMarkerJobInvalid(t *testing.T) {
+	pkg := types.NewPackage("example.com/store", "store")
+
+	for _, marker := range []string{"logging output", "logging bogus=1", "logging redact=[a"} {
+		t.Run(marker, func(t *testing.T) {
+			_, err := markerJob(marker, Options{}, pkg, "Store", ".", false)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -2,19 +2,180 @@ package interfaces
 
 import (
 	"bytes"
-	"go/format"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"text/template"
 )
 
-// InterfaceWrapperTemplate returns the filled template with Interface data
+// Supported kinds of generated middleware
+const (
+	MiddlewareLogging        = "logging"
+	MiddlewareMetrics        = "metrics"
+	MiddlewareTracing        = "tracing"
+	MiddlewareRetry          = "retry"
+	MiddlewareCircuitBreaker = "circuitbreaker"
+	MiddlewareTimeout        = "timeout"
+)
+
+// MiddlewareKinds returns the names of all supported middleware kinds
+func MiddlewareKinds() []string {
+	return []string{MiddlewareLogging, MiddlewareMetrics, MiddlewareTracing, MiddlewareRetry, MiddlewareCircuitBreaker, MiddlewareTimeout}
+}
+
+// kindTemplate contains the template of a middleware kind other than
+// logging and the imports it reserves. It is rendered on its own, the
+// template of the selected logger is only used by the logging kind.
+type kindTemplate struct {
+	imports []Import
+	tmpl    string
+}
+
+var kindTemplates = map[string]kindTemplate{
+	MiddlewareMetrics: {
+		imports: []Import{{Package: "prometheus", Path: "github.com/prometheus/client_golang/prometheus"}},
+		tmpl:    metricsTmpl,
+	},
+	MiddlewareTracing: {
+		imports: []Import{
+			{Package: "context", Path: "context"},
+			{Package: "otel", Path: "go.opentelemetry.io/otel"},
+			{Package: "attribute", Path: "go.opentelemetry.io/otel/attribute"},
+			{Package: "codes", Path: "go.opentelemetry.io/otel/codes"},
+			{Package: "trace", Path: "go.opentelemetry.io/otel/trace"},
+		},
+		tmpl: tracingTmpl,
+	},
+	MiddlewareRetry: {
+		imports: []Import{
+			{Package: "context", Path: "context"},
+			{Package: "errors", Path: "errors"},
+			{Package: "math", Path: "math"},
+			{Package: "rand", Path: "math/rand"},
+		},
+		tmpl: retryTmpl,
+	},
+	MiddlewareCircuitBreaker: {
+		imports: []Import{{Package: "sync", Path: "sync"}},
+		tmpl:    circuitBreakerTmpl,
+	},
+	MiddlewareTimeout: {
+		imports: []Import{{Package: "context", Path: "context"}},
+		tmpl:    timeoutTmpl,
+	},
+}
+
+func validateKind(kind string) error {
+	if kind == "" {
+		return nil
+	}
+
+	for _, k := range MiddlewareKinds() {
+		if k == kind {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("Unknown middleware kind %q, supported kinds are: %v", kind, strings.Join(MiddlewareKinds(), ", "))
+}
+
+// Render returns the generated middleware for Interface. User supplied
+// templates of options are used if present, the built-in template otherwise.
+func Render(i *Interface, options Options) ([]byte, error) {
+	if len(options.Templates) > 0 {
+		return CustomTemplate(i, options.Templates, options.TemplateDirs)
+	}
+
+	return InterfaceWrapperTemplate(i)
+}
+
+// InterfaceWrapperTemplate returns the filled template of the middleware
+// kind of Interface with Interface data
 func InterfaceWrapperTemplate(i *Interface) ([]byte, error) {
+	if kind, ok := kindTemplates[i.Kind]; ok {
+		t := template.Must(template.New("tmpl").Funcs(templateFuncs(i, kind.imports)).Parse(kind.tmpl))
+		template.Must(t.Parse(commonTmpl))
+
+		return executeTemplate(t, i)
+	}
+
+	backend, err := loggerTemplate(i.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	t := template.Must(template.New("tmpl").Funcs(templateFuncs(i, backend.imports)).Parse(tmpl))
+	template.Must(t.Parse(commonTmpl))
+	template.Must(t.Parse(backend.tmpl))
+
+	return executeTemplate(t, i)
+}
+
+// CustomTemplate returns the filled user supplied template files with Interface data.
+// The first file is executed, all others may contain shared template definitions.
+// Files which don't exist are looked up in dirs. The definitions of the
+// built-in logger template are available too.
+func CustomTemplate(i *Interface, files []string, dirs []string) ([]byte, error) {
+	if len(files) == 0 {
+		return nil, fmt.Errorf("No template file provided")
+	}
+
+	backend, err := loggerTemplate(i.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	t := template.Must(template.New("logger").Funcs(templateFuncs(i, backend.imports)).Parse(commonTmpl))
+	template.Must(t.Parse(backend.tmpl))
+
+	var main *template.Template
+	for _, file := range files {
+		path, err := resolveTemplateFile(file, dirs)
+		if err != nil {
+			return nil, err
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("Reading template %q: %v", path, err)
+		}
+
+		tf, err := t.New(path).Parse(string(content))
+		if err != nil {
+			return nil, err
+		}
+
+		if main == nil {
+			main = tf
+		}
+	}
+
+	return executeTemplate(main, i)
+}
+
+func resolveTemplateFile(file string, dirs []string) (string, error) {
+	if _, err := os.Stat(file); err == nil || filepath.IsAbs(file) {
+		return file, nil
+	}
+
+	for _, dir := range dirs {
+		path := filepath.Join(dir, file)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+
+	return "", fmt.Errorf("Template %q not found in working directory or template directories %v", file, dirs)
+}
+
+func executeTemplate(t *template.Template, i *Interface) ([]byte, error) {
 	buf := &bytes.Buffer{}
-	t := template.Must(template.New("tmpl").Parse(tmpl))
 	if err := t.Execute(buf, i); err != nil {
 		return nil, err
 	}
 
-	pretty, err := format.Source(buf.Bytes())
+	pretty, err := formatImports(buf.Bytes(), i.LocalPrefix)
 	if err != nil {
 		return buf.Bytes(), err
 	}
@@ -22,47 +183,100 @@ func InterfaceWrapperTemplate(i *Interface) ([]byte, error) {
 	return pretty, nil
 }
 
+// commonTmpl contains the definitions shared by all templates
+var commonTmpl = `
+{{define "imports"}}
+import (
+    {{- range imports}}
+    {{if ne .Package (base .Path)}}{{.Package}} {{end}}"{{.Path}}"
+    {{- end}}
+)
+{{- end}}
+`
+
 var tmpl = `// Code generated by github.com/hanofzelbri/middleware-generato; DO NOT EDIT
 
 package {{.WrapperPackageName}}
+{{template "imports"}}
 
-import (
-    "time"
+{{if .Comment}}{{.Comment}}{{end -}}
+type {{.WrapperStructName}}{{.TypeParamList}} struct {
+    {{wrapperField}} {{.TypeName}}
+    {{optionsField}} {{.WrapperStructName}}Options
+}
 
-    {{range .Imports}}
-    "{{.Path}}"
-    {{- end}}
-    "github.com/rs/zerolog/log"
-)
+type {{.WrapperStructName}}Options struct {
+    logger   {{template "loggerType"}}
+    injected bool
+    fields   {{template "fieldsType"}}
+    prefix   string
+}
 
-{{if .Comment}}{{.Comment}}{{end -}}
-type {{.WrapperStructName}} struct {
-    wrapper {{.Name}}
+// {{.MiddleWareFunctionName}}Option configures the middleware created by {{.MiddleWareFunctionName}}
+type {{.MiddleWareFunctionName}}Option func(*{{.WrapperStructName}}Options)
+
+// {{.MiddleWareFunctionName}}Logger sets the logger of the middleware, defaults to {{template "defaultLogger"}} looked up on every call
+func {{.MiddleWareFunctionName}}Logger(logger {{template "loggerType"}}) {{.MiddleWareFunctionName}}Option {
+    return func(o *{{.WrapperStructName}}Options) {
+        o.logger = logger
+        o.injected = true
+    }
+}
+
+// {{.MiddleWareFunctionName}}Fields adds base fields to every log entry of the middleware
+func {{.MiddleWareFunctionName}}Fields(fields {{template "fieldsParam"}}) {{.MiddleWareFunctionName}}Option {
+    return func(o *{{.WrapperStructName}}Options) {
+        {{- template "addFields"}}
+    }
+}
+
+// {{.MiddleWareFunctionName}}MessagePrefix prefixes every log message of the middleware
+func {{.MiddleWareFunctionName}}MessagePrefix(prefix string) {{.MiddleWareFunctionName}}Option {
+    return func(o *{{.WrapperStructName}}Options) {
+        o.prefix = prefix
+    }
 }
 
 // {{.MiddleWareFunctionName}} adds logging for interface {{.Name}}
-func {{.MiddleWareFunctionName}}(wrapper {{.Name}}) {{.Name}} {
-    return &{{.WrapperStructName}}{
-        wrapper: wrapper,
+func {{.MiddleWareFunctionName}}{{.TypeParamList}}(wrapper {{.TypeName}}, opts ...{{.MiddleWareFunctionName}}Option) {{.TypeName}} {
+    options := {{.WrapperStructName}}Options{}
+    for _, opt := range opts {
+        opt(&options)
     }
+    if options.injected {
+        options.logger = options.withFields(options.logger)
+    }
+    {{- template "applyFields"}}
+
+    return &{{.WrapperStructName}}{{.TypeArgList}}{
+        {{wrapperField}}: wrapper,
+        {{optionsField}}: options,
+    }
+}
+
+// currentLogger returns the injected logger or else the global logger, which
+// isn't stored by the constructor so that replacing it takes effect
+func (o *{{.WrapperStructName}}Options) currentLogger() {{template "loggerType"}} {
+    if o.injected {
+        return o.logger
+    }
+
+    return o.withFields({{template "defaultLogger"}})
+}
+
+func (o *{{.WrapperStructName}}Options) withFields(logger {{template "loggerType"}}) {{template "loggerType"}} {
+    {{- template "withFields"}}
+    return logger
 }
 
 {{range .Functions}}
 {{if .Comment}}{{.Comment}}{{end -}}
-func (l *{{$.WrapperStructName}}) {{.Name}}({{range .Params}}{{.Name}} {{.Type.Name}}, {{end}}) ({{range .Res}}{{.Name}} {{.Type.Name}}, {{end}}) {
+func (l *{{$.WrapperStructName}}{{$.TypeArgList}}) {{.Name}}({{range .Params}}{{.Name}} {{.Type.Name}}, {{end}}) ({{range .Res}}{{.Name}} {{.Type.Name}}, {{end}}) {
     defer func(begin time.Time) {
-        log.Info().
-            {{range .Params}}
-                Interface("{{.Name}}", {{.Name}}).
-            {{end}}
-            Dur("took", time.Since(begin)).
-            {{range .Res}}
-                Interface("{{.Name}}", {{.Name}}).
-            {{end}}
-            Msg("Method {{.Name}} called")
+        {{- template "log" .}}
     }(time.Now())
 
-    {{if .Res}}return{{end}} l.wrapper.{{.Name}}({{range $i, $p := .Params}}{{if $i}},{{end}}{{$p.Name}}{{end}}{{if .IsVariadic}}...{{end}})
+    {{if .Res}}return{{end}} l.{{wrapperField}}.{{.Name}}({{range $i, $p := .Params}}{{if $i}},{{end}}{{$p.Name}}{{end}}{{if .IsVariadic}}...{{end}})
 }
 {{end}}
 `
@@ -0,0 +1,708 @@
+package interfaces
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterfaceWrapperTemplateLoggers(t *testing.T) {
+	tests := []struct {
+		logger   string
+		contains []string
+		wantErr  bool
+	}{
+		{
+			logger:   "",
+			contains: []string{`"github.com/rs/zerolog/log"`, `return o.withFields(log.Logger)`, `logger.Info()`},
+		},
+		{
+			logger:   LoggerZerolog,
+			contains: []string{`"github.com/rs/zerolog/log"`, `Interface("a", a)`},
+		},
+		{
+			logger:   LoggerSlog,
+			contains: []string{`"log/slog"`, `logger.Info(l.options.prefix+"Method Array called"`},
+		},
+		{
+			logger:   LoggerZap,
+			contains: []string{`"go.uber.org/zap"`, `zap.Any("a", a)`},
+		},
+		{
+			logger:   LoggerLogrus,
+			contains: []string{`"github.com/sirupsen/logrus"`, `entry := logger.WithFields(logrus.Fields{`},
+		},
+		{
+			logger:   LoggerStdlib,
+			contains: []string{`"log"`, `logger.Printf("[%v] %vMethod Array called a=%v took=%v r=%v"`},
+		},
+		{
+			logger:   LoggerGoKit,
+			contains: []string{`"github.com/go-kit/log"`, `"msg", l.options.prefix+"Method Array called"`},
+		},
+		{
+			logger:  "unknown",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.logger, func(t *testing.T) {
+			i := *CompositeParamsInterfaceInterface
+			i.Logger = tt.logger
+
+			got, err := InterfaceWrapperTemplate(&i)
+			assert.Equal(t, tt.wantErr, err != nil, "%v", err)
+			for _, c := range tt.contains {
+				assert.Contains(t, string(got), c)
+			}
+		})
+	}
+}
+
+func TestCustomTemplate(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"main.tmpl":   "package {{.WrapperPackageName}}\n\n{{template \"names\" .}}\n",
+		"names.tmpl":  "{{define \"names\"}}{{range .Functions}}// {{.Name}}\n{{end}}{{end}}",
+		"parse.tmpl":  "package x\n\n{{if}}\n",
+		"exec.tmpl":   "package x\n\n{{.Unknown}}\n",
+		"logger.tmpl": "package x\n\nfunc f() {\n{{range .Functions}}{{template \"log\" .}}{{end}}\n}\n",
+	}
+	for name, content := range files {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	tests := []struct {
+		name     string
+		files    []string
+		dirs     []string
+		contains string
+		wantErr  string
+	}{
+		{
+			name:     "absolute path",
+			files:    []string{filepath.Join(dir, "main.tmpl"), filepath.Join(dir, "names.tmpl")},
+			contains: "// Map\n// Slice\n",
+		},
+		{
+			name:     "template directory",
+			files:    []string{"main.tmpl", "names.tmpl"},
+			dirs:     []string{t.TempDir(), dir},
+			contains: "package interfaces",
+		},
+		{
+			name:     "built-in definitions",
+			files:    []string{"logger.tmpl"},
+			dirs:     []string{dir},
+			contains: `Msg(l.options.prefix + "Method Map called")`,
+		},
+		{
+			name:    "missing file",
+			files:   []string{"missing.tmpl"},
+			dirs:    []string{dir},
+			wantErr: `Template "missing.tmpl" not found`,
+		},
+		{
+			name:    "parse error",
+			files:   []string{"parse.tmpl"},
+			dirs:    []string{dir},
+			wantErr: filepath.Join(dir, "parse.tmpl") + ":3",
+		},
+		{
+			name:    "execution error",
+			files:   []string{"exec.tmpl"},
+			dirs:    []string{dir},
+			wantErr: filepath.Join(dir, "exec.tmpl") + ":3:2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CustomTemplate(CompositeParamsInterfaceInterface, tt.files, tt.dirs)
+			if tt.wantErr != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tt.wantErr)
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Contains(t, string(got), tt.contains)
+		})
+	}
+}
+
+func TestZerologField(t *testing.T) {
+	tests := []struct {
+		param Param
+		want  string
+	}{
+		{param: Param{Name: "s", Type: Type{Name: "string", Kind: KindString, Basic: "string"}}, want: `Str("s", s)`},
+		{param: Param{Name: "id", Type: Type{Name: "pkg.ID", Kind: KindString, Basic: "string"}}, want: `Str("id", string(id))`},
+		{param: Param{Name: "b", Type: Type{Name: "bool", Kind: KindBool, Basic: "bool"}}, want: `Bool("b", b)`},
+		{param: Param{Name: "i", Type: Type{Name: "int32", Kind: KindInt, Basic: "int32"}}, want: `Int32("i", i)`},
+		{param: Param{Name: "u", Type: Type{Name: "uintptr", Kind: KindUint, Basic: "uintptr"}}, want: `Uint64("u", uint64(u))`},
+		{param: Param{Name: "f", Type: Type{Name: "float64", Kind: KindFloat, Basic: "float64"}}, want: `Float64("f", f)`},
+		{param: Param{Name: "err", Type: Type{Name: "error", Kind: KindError}}, want: `AnErr("err", err)`},
+		{param: Param{Name: "id", Type: Type{Name: "uuid.UUID", Kind: KindStringer}}, want: `Stringer("id", id)`},
+		{param: Param{Name: "t", Type: Type{Name: "time.Time", Kind: KindTime}}, want: `Time("t", t)`},
+		{param: Param{Name: "d", Type: Type{Name: "time.Duration", Kind: KindDuration}}, want: `Dur("d", d)`},
+		{param: Param{Name: "p", Type: Type{Name: "[]byte", Kind: KindBytes, Basic: "[]byte"}}, want: `Bytes("p", p)`},
+		{param: Param{Name: "f", Type: Type{Name: "func()", Kind: KindFunc}}, want: ``},
+		{param: Param{Name: "c", Type: Type{Name: "chan int", Kind: KindChan}}, want: ``},
+		{param: Param{Name: "m", Type: Type{Name: "map[string]int"}}, want: `Interface("m", m)`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, zerologField(tt.param, RedactModePlaceholder))
+		})
+	}
+}
+
+func TestInterfaceWrapperTemplateLevels(t *testing.T) {
+	tests := []struct {
+		name     string
+		iface    *Interface
+		levels   [3]string
+		contains []string
+	}{
+		{
+			name:     "defaults with error result",
+			iface:    TestInterface1Interface,
+			contains: []string{"event := logger.Info\n", "event = logger.Error\n", "Err(err)."},
+		},
+		{
+			name:     "configured levels with error result",
+			iface:    TestInterface1Interface,
+			levels:   [3]string{LevelWarn, LevelDebug, LevelWarn},
+			contains: []string{"event := logger.Debug\n", "event = logger.Warn\n"},
+		},
+		{
+			name:     "default level without error result",
+			iface:    UnnammedParametersInterfaceInterface,
+			levels:   [3]string{LevelWarn, LevelDebug, LevelError},
+			contains: []string{"logger.Warn().\n\t\t\tStr(\"paramName1\", paramName1)"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := *tt.iface
+			i.Level, i.SuccessLevel, i.ErrorLevel = tt.levels[0], tt.levels[1], tt.levels[2]
+
+			got, err := InterfaceWrapperTemplate(&i)
+			assert.NoError(t, err)
+			for _, c := range tt.contains {
+				assert.Contains(t, string(got), c)
+			}
+		})
+	}
+}
+
+func TestInterfaceWrapperTemplateOptions(t *testing.T) {
+	tests := []struct {
+		logger   string
+		contains []string
+	}{
+		{
+			logger: LoggerZerolog,
+			contains: []string{
+				"type WithWrapperOption func(*compositeParamsInterfaceOptions)",
+				"func WithWrapperLogger(logger zerolog.Logger) WithWrapperOption {",
+				"func WithWrapperFields(fields map[string]interface{}) WithWrapperOption {",
+				"func WithWrapperMessagePrefix(prefix string) WithWrapperOption {",
+				"func WithWrapper(wrapper CompositeParamsInterface, opts ...WithWrapperOption) CompositeParamsInterface {",
+				"logger = logger.With().Fields(o.fields).Logger()",
+				"options.logger = options.withFields(options.logger)",
+			},
+		},
+		{
+			logger:   LoggerSlog,
+			contains: []string{"logger *slog.Logger", "return o.withFields(slog.Default())", "func WithWrapperFields(fields ...any) WithWrapperOption {"},
+		},
+		{
+			logger:   LoggerZap,
+			contains: []string{"logger *zap.Logger", "return o.withFields(zap.L())", "func WithWrapperFields(fields ...zap.Field) WithWrapperOption {"},
+		},
+		{
+			logger:   LoggerLogrus,
+			contains: []string{"logger logrus.FieldLogger", "return o.withFields(logrus.StandardLogger())", "func WithWrapperFields(fields logrus.Fields) WithWrapperOption {"},
+		},
+		{
+			logger:   LoggerStdlib,
+			contains: []string{"logger *log.Logger", "return o.withFields(log.Default())", `options.prefix += fmt.Sprintf("%v=%v ", options.fields[i], options.fields[i+1])`},
+		},
+		{
+			logger:   LoggerGoKit,
+			contains: []string{"logger log.Logger", "return o.withFields(log.NewLogfmtLogger(os.Stderr))", "logger = log.With(logger, o.fields...)"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.logger, func(t *testing.T) {
+			i := *CompositeParamsInterfaceInterface
+			i.Logger = tt.logger
+			i.MiddleWareFunctionName = "WithWrapper"
+
+			got, err := InterfaceWrapperTemplate(&i)
+			assert.NoError(t, err)
+			for _, c := range tt.contains {
+				assert.Contains(t, string(got), c)
+			}
+		})
+	}
+}
+
+func TestInterfaceWrapperTemplateContext(t *testing.T) {
+	tests := []struct {
+		logger      string
+		contains    []string
+		notContains []string
+	}{
+		{
+			logger:      LoggerZerolog,
+			contains:    []string{"if ctxLogger := zerolog.Ctx(ctx); ctxLogger.GetLevel() != zerolog.Disabled {", "logger = l.options.withFields(*ctxLogger)", "event := logger.Info\n"},
+			notContains: []string{`Interface("ctx", ctx)`},
+		},
+		{
+			logger:      LoggerSlog,
+			contains:    []string{"level := slog.LevelInfo\n", "logger.Log(ctx, level, ", "logger.Log(ctx, slog.LevelInfo, "},
+			notContains: []string{`slog.Any("ctx", ctx)`},
+		},
+		{
+			logger:      LoggerLogrus,
+			contains:    []string{"logger = ctxLogger.WithContext(ctx)"},
+			notContains: []string{`"ctx": ctx`},
+		},
+		{
+			logger:      LoggerZap,
+			notContains: []string{`zap.Any("ctx", ctx)`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.logger, func(t *testing.T) {
+			i := *ContextParamsInterfaceInterface
+			i.Logger = tt.logger
+
+			got, err := InterfaceWrapperTemplate(&i)
+			assert.NoError(t, err)
+			for _, c := range tt.contains {
+				assert.Contains(t, string(got), c)
+			}
+			for _, c := range tt.notContains {
+				assert.NotContains(t, string(got), c)
+			}
+		})
+	}
+}
+
+func TestInterfaceWrapperTemplateRedaction(t *testing.T) {
+	password := Param{Name: "password", Type: Type{Name: "string", Kind: KindString, Basic: "string"}, Redacted: true}
+	key := Param{Name: "key", Type: Type{Name: "uuid.UUID", Kind: KindStringer}, Redacted: true}
+	err := Param{Name: "err", Type: Type{Name: "error", Kind: KindError}, Redacted: true}
+
+	tests := []struct {
+		logger   string
+		mode     string
+		contains []string
+	}{
+		{
+			logger:   LoggerZerolog,
+			contains: []string{`Str("password", "[REDACTED]")`, `Str("key", "[REDACTED]")`, `Str("err", "[REDACTED]")`},
+		},
+		{
+			logger:   LoggerZerolog,
+			mode:     RedactModeLength,
+			contains: []string{`Str("password", fmt.Sprintf("[REDACTED len=%d]", len(password)))`, `Str("key", fmt.Sprintf("[REDACTED len=%d]", len(fmt.Sprint(key))))`},
+		},
+		{
+			logger:   LoggerSlog,
+			mode:     RedactModeHash,
+			contains: []string{`slog.String("password", fmt.Sprintf("[REDACTED sha256=%.4x]", sha256.Sum256([]byte(fmt.Sprint(password)))))`},
+		},
+		{
+			logger:   LoggerZap,
+			contains: []string{`zap.String("password", "[REDACTED]")`},
+		},
+		{
+			logger:   LoggerLogrus,
+			contains: []string{`"password": "[REDACTED]",`},
+		},
+		{
+			logger:   LoggerStdlib,
+			contains: []string{`"[REDACTED]",`},
+		},
+		{
+			logger:   LoggerGoKit,
+			contains: []string{`"password", "[REDACTED]",`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.logger+tt.mode, func(t *testing.T) {
+			i := &Interface{
+				Name:                   "Login",
+				Functions:              []Func{{Name: "Login", Params: []Param{password, key}, Res: []Param{err}}},
+				Imports:                []Import{{Package: "uuid", Path: "github.com/google/uuid"}},
+				WrapperPackageName:     "tests",
+				WrapperStructName:      "login",
+				MiddleWareFunctionName: "WithLogin",
+				Logger:                 tt.logger,
+				RedactMode:             tt.mode,
+			}
+			i.Imports = append(i.Imports, redactImports(i)...)
+
+			got, err := InterfaceWrapperTemplate(i)
+			assert.NoError(t, err, string(got))
+			for _, c := range tt.contains {
+				assert.Contains(t, string(got), c)
+			}
+			assert.NotContains(t, string(got), ", password)")
+			assert.NotContains(t, string(got), ", password,")
+			assert.NotContains(t, string(got), "Err(err)")
+			assert.NotContains(t, string(got), ", err)")
+			assert.NotContains(t, string(got), ", err,")
+		})
+	}
+}
+
+func TestInterfaceWrapperTemplateGeneric(t *testing.T) {
+	i := *GenericInterfaceInterface
+	i.Logger = LoggerZerolog
+
+	got, err := InterfaceWrapperTemplate(&i)
+	assert.NoError(t, err)
+	for _, c := range []string{
+		"type Wrapper[K comparable, V any, N interfaces.NumberConstraint] struct {",
+		"wrapper interfaces.GenericInterface[K, V, N]",
+		"func WithWrapper[K comparable, V any, N interfaces.NumberConstraint](wrapper interfaces.GenericInterface[K, V, N], ",
+		"return &Wrapper[K, V, N]{",
+		"func (l *Wrapper[K, V, N]) Put(",
+	} {
+		assert.Contains(t, string(got), c)
+	}
+}
+
+func TestInterfaceWrapperTemplateFieldNames(t *testing.T) {
+	i := &Interface{
+		Name:                   "Colliding",
+		WrapperPackageName:     "tests",
+		WrapperStructName:      "wrapper",
+		MiddleWareFunctionName: "WithWrapper",
+		Logger:                 LoggerSlog,
+		Functions:              []Func{{Name: "wrapper"}, {Name: "options"}},
+	}
+
+	got, err := InterfaceWrapperTemplate(i)
+	assert.NoError(t, err)
+	for _, c := range []string{"wrapper2 Colliding", "options2 wrapperOptions", "wrapper2: wrapper,", "l.wrapper2.wrapper()", "logger := l.options2.currentLogger()"} {
+		assert.Contains(t, string(got), c)
+	}
+}
+
+func TestInterfaceWrapperTemplateKinds(t *testing.T) {
+	noRetry := *ContextParamsInterfaceInterface
+	noRetry.Functions = append([]Func{}, noRetry.Functions...)
+	noRetry.Functions[0].NoRetry = true
+
+	methodTimeout := *ContextParamsInterfaceInterface
+	methodTimeout.Functions = append([]Func{}, methodTimeout.Functions...)
+	methodTimeout.Functions[1].Timeout = 1500 * time.Millisecond
+
+	redactedError := *ContextParamsInterfaceInterface
+	redactedError.Functions = append([]Func{}, redactedError.Functions...)
+	redactedError.Functions[0].Res = append([]Param{}, redactedError.Functions[0].Res...)
+	redactedError.Functions[0].Res[0].Redacted = true
+
+	tests := []struct {
+		name        string
+		kind        string
+		inter       *Interface
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:  "metrics error result",
+			kind:  MiddlewareMetrics,
+			inter: ContextParamsInterfaceInterface,
+			contains: []string{
+				`"github.com/prometheus/client_golang/prometheus"`,
+				"func WithWrapper(wrapper ContextParamsInterface, registerer prometheus.Registerer, opts ...WithWrapperOption) ContextParamsInterface {",
+				"func WithWrapperNamespace(namespace string) WithWrapperOption {",
+				"func WithWrapperBuckets(buckets ...float64) WithWrapperOption {",
+				`Name:      "method_calls_total",`,
+				`Name:      "method_errors_total",`,
+				`Name:      "method_duration_seconds",`,
+				`l.calls.WithLabelValues("ContextParamsInterface", "Context").Inc()`,
+				`l.duration.WithLabelValues("ContextParamsInterface", "Context").Observe(time.Since(begin).Seconds())`,
+				"if returnName1 != nil {\n\t\t\tl.errors.WithLabelValues(\"ContextParamsInterface\", \"Context\").Inc()",
+			},
+			notContains: []string{"zerolog", `errors.WithLabelValues("ContextParamsInterface", "ContextWithoutError")`},
+		},
+		{
+			name:  "metrics generic",
+			kind:  MiddlewareMetrics,
+			inter: GenericInterfaceInterface,
+			contains: []string{
+				"func WithWrapper[K comparable, V any, N interfaces.NumberConstraint](wrapper interfaces.GenericInterface[K, V, N], registerer prometheus.Registerer, ",
+				`l.calls.WithLabelValues("GenericInterface", "Put").Inc()`,
+			},
+		},
+		{
+			name:  "tracing context",
+			kind:  MiddlewareTracing,
+			inter: ContextParamsInterfaceInterface,
+			contains: []string{
+				`"go.opentelemetry.io/otel/trace"`,
+				"func WithWrapperTracerProvider(tracerProvider trace.TracerProvider) WithWrapperOption {",
+				"ctx, span := l.tracer.Start(ctx, \"ContextParamsInterface.Context\", trace.WithAttributes(\n\t\tattribute.String(\"id\", id),\n\t))",
+				"span.RecordError(returnName1)",
+				"span.SetStatus(codes.Error, returnName1.Error())",
+				"ctx, span := l.tracer.Start(ctx, \"ContextParamsInterface.ContextWithoutError\")\n\tdefer span.End()",
+				"return l.wrapper.Context(ctx, id)",
+			},
+			notContains: []string{"zerolog", `attribute.String("ctx"`},
+		},
+		{
+			name:        "tracing redacted error",
+			kind:        MiddlewareTracing,
+			inter:       &redactedError,
+			contains:    []string{"if returnName1 != nil {\n\t\t\tspan.SetStatus(codes.Error, \"[REDACTED]\")\n\t\t}"},
+			notContains: []string{"span.RecordError(returnName1)", "returnName1.Error()"},
+		},
+		{
+			name:  "tracing without context",
+			kind:  MiddlewareTracing,
+			inter: CompositeParamsInterfaceInterface,
+			contains: []string{
+				`_, span := l.tracer.Start(context.Background(), "CompositeParamsInterface.`,
+			},
+		},
+		{
+			name:  "retry context",
+			kind:  MiddlewareRetry,
+			inter: ContextParamsInterfaceInterface,
+			contains: []string{
+				"func WithWrapperMaxAttempts(maxAttempts int) WithWrapperOption {",
+				"returnName1 = l.wrapper.Context(ctx, id)\n\t\tif returnName1 == nil || attempt >= l.options.maxAttempts || !l.options.retryable(returnName1) {",
+				"if !l.options.wait(ctx, attempt) {",
+				"func (l *contextParamsInterface) ContextWithoutError(ctx context.Context) {\n\tl.wrapper.ContextWithoutError(ctx)\n}",
+			},
+			notContains: []string{"zerolog"},
+		},
+		{
+			name:  "retry without context",
+			kind:  MiddlewareRetry,
+			inter: LoggableParamsInterfaceInterface,
+			contains: []string{
+				"if !l.options.wait(context.Background(), attempt) {",
+			},
+		},
+		{
+			name:        "retry opt-out",
+			kind:        MiddlewareRetry,
+			inter:       &noRetry,
+			contains:    []string{"return l.wrapper.Context(ctx, id)"},
+			notContains: []string{"for attempt"},
+		},
+		{
+			name:  "circuit breaker",
+			kind:  MiddlewareCircuitBreaker,
+			inter: ContextParamsInterfaceInterface,
+			contains: []string{
+				"type WithWrapperErrCircuitOpen struct {",
+				`for _, method := range []string{"Context"} {`,
+				"func WithWrapperOnStateChange(onStateChange func(method string, from string, to string)) WithWrapperOption {",
+				"breaker := l.breakers[\"Context\"]\n\tgeneration, ok := breaker.allow(&l.options, \"Context\")\n\tif !ok {\n\t\treturnName1 = WithWrapperErrCircuitOpen{Interface: \"ContextParamsInterface\", Method: \"Context\"}",
+				"breaker.done(&l.options, \"Context\", generation, returnName1 != nil || recovered != nil)",
+				"func (l *contextParamsInterface) ContextWithoutError(ctx context.Context) {\n\tl.wrapper.ContextWithoutError(ctx)\n}",
+			},
+			notContains: []string{"zerolog"},
+		},
+		{
+			name:  "timeout",
+			kind:  MiddlewareTimeout,
+			inter: &methodTimeout,
+			contains: []string{
+				"type WithWrapperErrTimeout struct {",
+				"timeout: 10 * time.Second,\n\t\ttimeouts: map[string]time.Duration{\n\t\t\t\"ContextWithoutError\": 1500 * time.Millisecond,\n\t\t},",
+				"parent := ctx\n\tctx, cancel := context.WithTimeout(ctx, timeout)",
+				"returnName1 := l.wrapper.Context(ctx, id)",
+				"if returnName1 == context.DeadlineExceeded && parent.Err() == nil {",
+				"returnName1 = WithWrapperErrTimeout{Interface: \"ContextParamsInterface\", Method: \"Context\", Timeout: timeout}",
+				"timeout := l.options.timeoutOf(\"ContextWithoutError\")\n\tctx, cancel := context.WithTimeout(ctx, timeout)\n\tdefer cancel()\n\n\tl.wrapper.ContextWithoutError(ctx)\n}",
+			},
+			notContains: []string{"zerolog"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := *tt.inter
+			i.Kind = tt.kind
+
+			got, err := InterfaceWrapperTemplate(&i)
+			assert.NoError(t, err)
+			for _, c := range tt.contains {
+				assert.Contains(t, string(got), c)
+			}
+			for _, c := range tt.notContains {
+				assert.NotContains(t, string(got), c)
+			}
+		})
+	}
+}
+
+// kindModules are the modules required by the generated middlewares of
+// TestKindBehaviour
+var kindModules = []string{
+	"github.com/go-kit/log v0.2.1",
+	"github.com/prometheus/client_golang v1.20.5",
+	"github.com/rs/zerolog v1.33.0",
+	"github.com/sirupsen/logrus v1.9.3",
+	"go.opentelemetry.io/otel v1.32.0",
+	"go.opentelemetry.io/otel/sdk v1.32.0",
+	"go.opentelemetry.io/otel/trace v1.32.0",
+	"go.uber.org/zap v1.27.0",
+}
+
+// TestKindBehaviour generates middlewares for the interfaces of testdata/kinds
+// into a temporary module, which requires the libraries used by the
+// templates, and vets and tests the package against them
+func TestKindBehaviour(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test")
+	}
+
+	dir := t.TempDir()
+	mod := "module example.com/kinds\n\ngo 1.22\n\nrequire (\n"
+	for _, m := range kindModules {
+		mod += "\t" + m + "\n"
+	}
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte(mod+")\n"), 0644))
+
+	// Tests importing the libraries are kept in testdata/kinds/modules, the
+	// package loaded by BuildInterface would require them in go.mod otherwise
+	sources, err := filepath.Glob(filepath.Join("testdata", "kinds", "*.go"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	moduleSources, err := filepath.Glob(filepath.Join("testdata", "kinds", "modules", "*.go"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	sources = append(sources, moduleSources...)
+	for _, source := range sources {
+		content, err := os.ReadFile(source)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, filepath.Base(source)), content, 0644))
+	}
+
+	for _, m := range []struct {
+		inter    string
+		kind     string
+		logger   string
+		wrapper  string
+		function string
+	}{
+		{inter: "Service", kind: MiddlewareRetry, wrapper: "retryService", function: "WithRetry"},
+		{inter: "Service", kind: MiddlewareCircuitBreaker, wrapper: "breakerService", function: "WithBreaker"},
+		{inter: "Service", kind: MiddlewareTimeout, wrapper: "timeoutService", function: "WithTimeout"},
+		{inter: "Store", kind: MiddlewareMetrics, wrapper: "metricsStore", function: "WithMetrics"},
+		{inter: "Store", kind: MiddlewareTracing, wrapper: "tracingStore", function: "WithTracing"},
+		{inter: "Store", logger: LoggerZerolog, wrapper: "zerologStore", function: "WithZerolog"},
+		{inter: "Store", logger: LoggerSlog, wrapper: "slogStore", function: "WithSlog"},
+		{inter: "Store", logger: LoggerZap, wrapper: "zapStore", function: "WithZap"},
+		{inter: "Store", logger: LoggerLogrus, wrapper: "logrusStore", function: "WithLogrus"},
+		{inter: "Store", logger: LoggerStdlib, wrapper: "stdlibStore", function: "WithStdlib"},
+		{inter: "Store", logger: LoggerGoKit, wrapper: "gokitStore", function: "WithGokit"},
+	} {
+		inter, err := BuildInterface(Options{
+			Query:                              "github.com/hanofzelbri/middleware-generator/interfaces/testdata/kinds." + m.inter,
+			Wrapper:                            "kinds." + m.wrapper,
+			MiddlewareFunctionName:             m.function,
+			EmptyFunctionParamNamePrefix:       "param",
+			EmptyFunctionReturnParamNamePrefix: "ret",
+			Kind:                               m.kind,
+			Logger:                             m.logger,
+		})
+		if !assert.NoError(t, err) {
+			return
+		}
+		file, err := InterfaceWrapperTemplate(inter)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, FileName(&Interface{Name: m.wrapper})), file, 0644))
+	}
+
+	for _, args := range [][]string{{"vet", "-mod=mod", "."}, {"test", "-mod=mod", "-count=1", "."}} {
+		cmd := exec.Command("go", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		if !assert.NoError(t, err, string(out)) {
+			return
+		}
+	}
+}
+
+func TestOtelAttribute(t *testing.T) {
+	tests := []struct {
+		param Param
+		want  string
+	}{
+		{param: Param{Name: "s", Type: Type{Name: "string", Kind: KindString, Basic: "string"}}, want: `attribute.String("s", s)`},
+		{param: Param{Name: "n", Type: Type{Name: "Count", Kind: KindUint, Basic: "uint32"}}, want: `attribute.Int64("n", int64(n))`},
+		{param: Param{Name: "f", Type: Type{Name: "float32", Kind: KindFloat, Basic: "float32"}}, want: `attribute.Float64("f", float64(f))`},
+		{param: Param{Name: "d", Type: Type{Name: "time.Duration", Kind: KindDuration}}, want: `attribute.String("d", d.String())`},
+		{param: Param{Name: "id", Type: Type{Name: "uuid.UUID", Kind: KindStringer}}, want: `attribute.Stringer("id", id)`},
+		{param: Param{Name: "token", Type: Type{Name: "string", Kind: KindString}, Redacted: true}, want: `attribute.String("token", "[REDACTED]")`},
+		{param: Param{Name: "m", Type: Type{Name: "map[string]int"}}, want: ""},
+		{param: Param{Name: "ctx", Type: Type{Name: "context.Context", Kind: KindContext}}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.param.Name, func(t *testing.T) {
+			assert.Equal(t, tt.want, otelAttribute(tt.param, ""))
+		})
+	}
+}
+
+func TestOtelAttributes(t *testing.T) {
+	params := []Param{
+		{Name: "ctx", Type: Type{Name: "context.Context", Kind: KindContext}},
+		{Name: "id", Type: Type{Name: "string", Kind: KindString, Basic: "string"}},
+		{Name: "size", Type: Type{Name: "int", Kind: KindInt, Basic: "int"}},
+	}
+	tests := []struct {
+		name   string
+		traced []string
+		want   []string
+	}{
+		{name: "without annotation", want: []string{`attribute.String("id", id)`, `attribute.Int64("size", int64(size))`}},
+		{name: "annotation", traced: []string{"ctx", "size"}, want: []string{`attribute.Int64("size", int64(size))`}},
+		{name: "empty annotation", traced: []string{}, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, otelAttributes(Func{Traced: tt.traced, Params: params}, ""))
+		})
+	}
+}
+
+func TestDurationExpr(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{d: 2 * time.Hour, want: "2 * time.Hour"},
+		{d: 90 * time.Second, want: "90 * time.Second"},
+		{d: 1500 * time.Millisecond, want: "1500 * time.Millisecond"},
+		{d: 7, want: "7 * time.Nanosecond"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, durationExpr(tt.d))
+		})
+	}
+}
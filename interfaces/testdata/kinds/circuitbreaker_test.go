@@ -0,0 +1,227 @@
+package kinds
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// transitions records the state changes reported by OnStateChange
+type transitions struct {
+	mu  sync.Mutex
+	got []string
+}
+
+func (tr *transitions) option() WithBreakerOption {
+	return WithBreakerOnStateChange(func(method string, from string, to string) {
+		tr.mu.Lock()
+		defer tr.mu.Unlock()
+		tr.got = append(tr.got, method+": "+from+" -> "+to)
+	})
+}
+
+func (tr *transitions) assert(t *testing.T, want ...string) {
+	t.Helper()
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	if !reflect.DeepEqual(tr.got, want) {
+		t.Errorf("transitions = %q, want %q", tr.got, want)
+	}
+}
+
+var errCallOpen = WithBreakerErrCircuitOpen{Interface: "Service", Method: "Call"}
+
+func TestBreakerOpens(t *testing.T) {
+	tr := &transitions{}
+	s := newFakeService(errFailed, errFailed)
+	svc := WithBreaker(s, WithBreakerThreshold(2), WithBreakerCoolDown(time.Hour), tr.option())
+
+	for i := 0; i < 2; i++ {
+		if err := svc.Call(context.Background()); err != errFailed {
+			t.Fatalf("Call() = %v, want %v", err, errFailed)
+		}
+	}
+	if err := svc.Call(context.Background()); err != errCallOpen {
+		t.Fatalf("Call() = %v, want %v", err, errCallOpen)
+	}
+	if s.Calls() != 2 {
+		t.Errorf("calls = %d, want 2", s.Calls())
+	}
+	if _, err := svc.Lookup("key"); err != nil {
+		t.Errorf("Lookup() = %v, want its own breaker", err)
+	}
+	svc.Notify(context.Background())
+	if s.Calls() != 4 {
+		t.Errorf("calls = %d, want 4", s.Calls())
+	}
+	tr.assert(t, "Call: closed -> open")
+}
+
+func TestBreakerSuccessResetsFailures(t *testing.T) {
+	s := newFakeService(errFailed, nil, errFailed)
+	svc := WithBreaker(s, WithBreakerThreshold(2))
+
+	for i := 0; i < 4; i++ {
+		_ = svc.Call(context.Background())
+	}
+	if s.Calls() != 4 {
+		t.Errorf("calls = %d, want 4", s.Calls())
+	}
+}
+
+func TestBreakerHalfOpen(t *testing.T) {
+	tr := &transitions{}
+	s := newFakeService(errFailed, errFailed)
+	svc := WithBreaker(s, WithBreakerThreshold(1), WithBreakerCoolDown(20*time.Millisecond), tr.option())
+
+	if err := svc.Call(context.Background()); err != errFailed {
+		t.Fatalf("Call() = %v, want %v", err, errFailed)
+	}
+	if err := svc.Call(context.Background()); err != errCallOpen {
+		t.Fatalf("Call() = %v, want %v", err, errCallOpen)
+	}
+
+	// The failed trial opens the breaker again for another cool-down
+	time.Sleep(30 * time.Millisecond)
+	if err := svc.Call(context.Background()); err != errFailed {
+		t.Fatalf("Call() = %v, want %v", err, errFailed)
+	}
+	if err := svc.Call(context.Background()); err != errCallOpen {
+		t.Fatalf("Call() = %v, want %v", err, errCallOpen)
+	}
+
+	// The successful trial closes the breaker
+	time.Sleep(30 * time.Millisecond)
+	for i := 0; i < 2; i++ {
+		if err := svc.Call(context.Background()); err != nil {
+			t.Fatalf("Call() = %v, want nil", err)
+		}
+	}
+	tr.assert(t,
+		"Call: closed -> open",
+		"Call: open -> half-open",
+		"Call: half-open -> open",
+		"Call: open -> half-open",
+		"Call: half-open -> closed",
+	)
+}
+
+func TestBreakerPanic(t *testing.T) {
+	tr := &transitions{}
+	s := newFakeService(errFailed)
+	svc := WithBreaker(s, WithBreakerThreshold(1), WithBreakerCoolDown(20*time.Millisecond), tr.option())
+
+	if err := svc.Call(context.Background()); err != errFailed {
+		t.Fatalf("Call() = %v, want %v", err, errFailed)
+	}
+
+	// The panicking trial opens the breaker again and the panic is passed on
+	time.Sleep(30 * time.Millisecond)
+	s.panicValue = "boom"
+	recovered := func() (recovered interface{}) {
+		defer func() { recovered = recover() }()
+		_ = svc.Call(context.Background())
+		return nil
+	}()
+	if recovered != "boom" {
+		t.Fatalf("recovered %v, want the panic of the implementation", recovered)
+	}
+	if err := svc.Call(context.Background()); err != errCallOpen {
+		t.Fatalf("Call() = %v, want %v", err, errCallOpen)
+	}
+	tr.assert(t,
+		"Call: closed -> open",
+		"Call: open -> half-open",
+		"Call: half-open -> open",
+	)
+}
+
+func TestBreakerSingleTrial(t *testing.T) {
+	s := newBlockingService(errFailed)
+	defer close(s.release)
+	svc := WithBreaker(s, WithBreakerThreshold(1), WithBreakerCoolDown(20*time.Millisecond))
+
+	go func() { _ = svc.Call(context.Background()) }()
+	<-s.entered
+	s.release <- struct{}{}
+	time.Sleep(30 * time.Millisecond)
+
+	trial := make(chan error)
+	go func() { trial <- svc.Call(context.Background()) }()
+	<-s.entered
+
+	if err := svc.Call(context.Background()); err != errCallOpen {
+		t.Errorf("Call() = %v during the trial, want %v", err, errCallOpen)
+	}
+
+	s.release <- struct{}{}
+	if err := <-trial; err != nil {
+		t.Fatalf("trial Call() = %v, want nil", err)
+	}
+}
+
+func TestBreakerStaleResult(t *testing.T) {
+	tr := &transitions{}
+	s := newBlockingService(errFailed)
+	defer close(s.release)
+	svc := WithBreaker(s, WithBreakerThreshold(1), WithBreakerCoolDown(time.Hour), WithBreakerPerInterface(), tr.option())
+
+	// Passes while closed and succeeds after the breaker was opened
+	stale := make(chan error)
+	go func() { stale <- svc.Call(context.Background()) }()
+	<-s.entered
+
+	if _, err := svc.Lookup("key"); err != errFailed {
+		t.Fatalf("Lookup() = %v, want %v", err, errFailed)
+	}
+
+	s.release <- struct{}{}
+	if err := <-stale; err != nil {
+		t.Fatalf("stale Call() = %v, want nil", err)
+	}
+
+	if _, err := svc.Lookup("key"); err != (WithBreakerErrCircuitOpen{Interface: "Service", Method: "Lookup"}) {
+		t.Errorf("Lookup() = %v, want the breaker to stay open", err)
+	}
+	tr.assert(t, "Lookup: closed -> open")
+}
+
+func TestBreakerStaleResultDuringTrial(t *testing.T) {
+	tr := &transitions{}
+	s := newBlockingService(errFailed)
+	defer close(s.release)
+	svc := WithBreaker(s, WithBreakerThreshold(1), WithBreakerCoolDown(20*time.Millisecond), WithBreakerPerInterface(), tr.option())
+
+	stale := make(chan error)
+	go func() { stale <- svc.Call(context.Background()) }()
+	<-s.entered
+
+	if _, err := svc.Lookup("key"); err != errFailed {
+		t.Fatalf("Lookup() = %v, want %v", err, errFailed)
+	}
+	time.Sleep(30 * time.Millisecond)
+
+	trial := make(chan error)
+	go func() { trial <- svc.Call(context.Background()) }()
+	<-s.entered
+
+	// Calls blocked in Call are released in order, the stale one first
+	s.release <- struct{}{}
+	if err := <-stale; err != nil {
+		t.Fatalf("stale Call() = %v, want nil", err)
+	}
+	if _, err := svc.Lookup("key"); err != (WithBreakerErrCircuitOpen{Interface: "Service", Method: "Lookup"}) {
+		t.Errorf("Lookup() = %v during the trial, want %v", err, "circuit open")
+	}
+
+	s.release <- struct{}{}
+	if err := <-trial; err != nil {
+		t.Fatalf("trial Call() = %v, want nil", err)
+	}
+	if _, err := svc.Lookup("key"); err != nil {
+		t.Errorf("Lookup() = %v after the trial, want nil", err)
+	}
+	tr.assert(t, "Lookup: closed -> open", "Call: open -> half-open", "Call: half-open -> closed")
+}
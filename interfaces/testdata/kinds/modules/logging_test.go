@@ -0,0 +1,33 @@
+package kinds
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestZerologContextLogger(t *testing.T) {
+	injected := &bytes.Buffer{}
+	fromContext := &bytes.Buffer{}
+	store := WithZerolog(&fakeStore{},
+		WithZerologLogger(zerolog.New(injected)),
+		WithZerologFields(map[string]interface{}{"service": "store"}),
+	)
+
+	ctx := zerolog.New(fromContext).WithContext(context.Background())
+	if _, err := store.Get(ctx, "key"); err != nil {
+		t.Fatalf("Get() = %v", err)
+	}
+
+	if injected.Len() != 0 {
+		t.Errorf("injected logger got %q, want the context logger to be used", injected.String())
+	}
+	for _, want := range []string{`"service":"store"`, `"key":"key"`, `"message":"Method Get called"`} {
+		if !strings.Contains(fromContext.String(), want) {
+			t.Errorf("context logger got %q, want %s", fromContext.String(), want)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package kinds
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// metricValue returns the value of the counter or the sample count of the
+// histogram name of method
+func metricValue(t *testing.T, gatherer prometheus.Gatherer, name string, method string) float64 {
+	t.Helper()
+
+	families, err := gatherer.Gather()
+	if err != nil {
+		t.Fatalf("Gather() = %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			for _, label := range m.GetLabel() {
+				if label.GetName() != "method" || label.GetValue() != method {
+					continue
+				}
+				if m.GetHistogram() != nil {
+					return float64(m.GetHistogram().GetSampleCount())
+				}
+				return m.GetCounter().GetValue()
+			}
+		}
+	}
+
+	return 0
+}
+
+func TestMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	store := WithMetrics(&fakeStore{}, registry, WithMetricsNamespace("app"))
+	failing := WithMetrics(&fakeStore{err: errFailed}, registry, WithMetricsNamespace("app"))
+
+	_, _ = store.Get(context.Background(), "key")
+	_ = failing.Put(context.Background(), "key", nil, time.Minute)
+	_ = failing.Put(context.Background(), "key", nil, time.Minute)
+
+	for _, tt := range []struct {
+		name   string
+		method string
+		want   float64
+	}{
+		{name: "app_method_calls_total", method: "Get", want: 1},
+		{name: "app_method_calls_total", method: "Put", want: 2},
+		{name: "app_method_errors_total", method: "Get", want: 0},
+		{name: "app_method_errors_total", method: "Put", want: 2},
+		{name: "app_method_duration_seconds", method: "Put", want: 2},
+	} {
+		if got := metricValue(t, registry, tt.name, tt.method); got != tt.want {
+			t.Errorf("%v{method=%q} = %v, want %v", tt.name, tt.method, got, tt.want)
+		}
+	}
+}
+
+func TestMetricsWithoutRegisterer(t *testing.T) {
+	store := WithMetrics(&fakeStore{}, nil)
+
+	if _, err := store.Get(context.Background(), "key"); err != nil {
+		t.Errorf("Get() = %v", err)
+	}
+}
+
+func TestMetricsRegisterConflict(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(prometheus.NewCounter(prometheus.CounterOpts{Name: "method_calls_total", Help: "Other metric."}))
+
+	defer func() {
+		if recovered := recover(); recovered == nil {
+			t.Error("WithMetrics() didn't panic, want the registration error")
+		}
+	}()
+	WithMetrics(&fakeStore{}, registry)
+}
@@ -0,0 +1,92 @@
+package kinds
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// tracedStore returns store wrapped by the tracing middleware and the
+// recorder of its ended spans
+func tracedStore(store Store) (Store, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	return WithTracing(store, WithTracingTracerProvider(provider)), recorder
+}
+
+// attributes returns the attributes of span by key
+func attributes(span sdktrace.ReadOnlySpan) map[attribute.Key]string {
+	attrs := map[attribute.Key]string{}
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value.Emit()
+	}
+
+	return attrs
+}
+
+func TestTracing(t *testing.T) {
+	store, recorder := tracedStore(&fakeStore{})
+
+	ctx, parent := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "parent")
+	_, _ = store.Login(ctx, "user", "secret")
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	if spans[0].Name() != "Store.Login" {
+		t.Errorf("span name = %q, want Store.Login", spans[0].Name())
+	}
+	if spans[0].Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Error("span isn't a child of the span of the context")
+	}
+	want := map[attribute.Key]string{"user": "user", "password": "[REDACTED]"}
+	if got := attributes(spans[0]); len(got) != len(want) || got["user"] != want["user"] || got["password"] != want["password"] {
+		t.Errorf("attributes = %v, want %v", got, want)
+	}
+	if spans[0].Status().Code != codes.Unset {
+		t.Errorf("status = %v, want unset", spans[0].Status())
+	}
+}
+
+func TestTracingAnnotation(t *testing.T) {
+	store, recorder := tracedStore(&fakeStore{})
+
+	_ = store.Put(context.Background(), "key", []byte("value"), time.Minute)
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	want := map[attribute.Key]string{"key": "key"}
+	if got := attributes(spans[0]); len(got) != len(want) || got["key"] != want["key"] {
+		t.Errorf("attributes = %v, want only the annotated %v", got, want)
+	}
+}
+
+func TestTracingError(t *testing.T) {
+	store, recorder := tracedStore(&fakeStore{err: errFailed})
+
+	_, _ = store.List("prefix", 10, "a", "b")
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	if status := spans[0].Status(); status.Code != codes.Error || status.Description != errFailed.Error() {
+		t.Errorf("status = %v, want error %v", status, errFailed)
+	}
+	if events := spans[0].Events(); len(events) != 1 || events[0].Name != "exception" {
+		t.Errorf("events = %v, want the recorded error", events)
+	}
+	if got := attributes(spans[0]); got["prefix"] != "prefix" || got["limit"] != "10" {
+		t.Errorf("attributes = %v, want prefix and limit", got)
+	}
+}
@@ -0,0 +1,152 @@
+package kinds
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRetryUntilSuccess(t *testing.T) {
+	s := newFakeService(errFailed, errFailed)
+	svc := WithRetry(s, WithRetryBackoff(time.Millisecond, time.Millisecond, 1))
+
+	if err := svc.Call(context.Background()); err != nil {
+		t.Fatalf("Call() = %v, want nil", err)
+	}
+	if s.Calls() != 3 {
+		t.Errorf("calls = %d, want 3", s.Calls())
+	}
+}
+
+func TestRetryMaxAttempts(t *testing.T) {
+	s := newFakeService(errFailed, errFailed, errFailed)
+	svc := WithRetry(s, WithRetryMaxAttempts(2), WithRetryBackoff(time.Millisecond, time.Millisecond, 1))
+
+	if err := svc.Call(context.Background()); err != errFailed {
+		t.Fatalf("Call() = %v, want %v", err, errFailed)
+	}
+	if s.Calls() != 2 {
+		t.Errorf("calls = %d, want 2", s.Calls())
+	}
+}
+
+func TestRetryRetryable(t *testing.T) {
+	permanent := errors.New("permanent")
+	s := newFakeService(permanent)
+	svc := WithRetry(s, WithRetryRetryable(func(err error) bool { return err != permanent }))
+
+	if err := svc.Call(context.Background()); err != permanent {
+		t.Fatalf("Call() = %v, want %v", err, permanent)
+	}
+	if s.Calls() != 1 {
+		t.Errorf("calls = %d, want 1", s.Calls())
+	}
+
+	s = newFakeService(context.Canceled)
+	if err := WithRetry(s).Call(context.Background()); err != context.Canceled {
+		t.Fatalf("Call() = %v, want %v", err, context.Canceled)
+	}
+	if s.Calls() != 1 {
+		t.Errorf("calls = %d, want 1, context errors aren't retried by default", s.Calls())
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	s := newFakeService(errFailed, errFailed, errFailed)
+	svc := WithRetry(s, WithRetryMaxAttempts(4), WithRetryBackoff(20*time.Millisecond, 30*time.Millisecond, 2), WithRetryJitter(0))
+
+	begin := time.Now()
+	if err := svc.Call(context.Background()); err != nil {
+		t.Fatalf("Call() = %v, want nil", err)
+	}
+	// 20ms, then 40ms capped to 30ms twice
+	if took := time.Since(begin); took < 80*time.Millisecond {
+		t.Errorf("took %v, want at least 80ms", took)
+	}
+
+	s = newFakeService(errFailed, errFailed)
+	svc = WithRetry(s, WithRetryBackoff(10*time.Millisecond, 20*time.Millisecond, 1000))
+
+	begin = time.Now()
+	if err := svc.Call(context.Background()); err != nil {
+		t.Fatalf("Call() = %v, want nil", err)
+	}
+	if took := time.Since(begin); took > 5*time.Second {
+		t.Errorf("took %v, want the backoff to be capped at 20ms", took)
+	}
+}
+
+func TestRetryJitter(t *testing.T) {
+	const backoff = 40 * time.Millisecond
+
+	shorter := false
+	for i := 0; i < 20; i++ {
+		s := newFakeService(errFailed)
+		svc := WithRetry(s, WithRetryBackoff(backoff, backoff, 1), WithRetryJitter(0.5))
+
+		begin := time.Now()
+		if err := svc.Call(context.Background()); err != nil {
+			t.Fatalf("Call() = %v, want nil", err)
+		}
+		took := time.Since(begin)
+		if took < backoff/2 {
+			t.Fatalf("took %v, want at least %v", took, backoff/2)
+		}
+		shorter = shorter || took < backoff
+	}
+	if !shorter {
+		t.Errorf("no backoff was decreased by the jitter")
+	}
+}
+
+func TestRetryContextDone(t *testing.T) {
+	s := newFakeService(errFailed, errFailed)
+	svc := WithRetry(s, WithRetryBackoff(time.Hour, time.Hour, 1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	begin := time.Now()
+	if err := svc.Call(ctx); err != errFailed {
+		t.Fatalf("Call() = %v, want %v", err, errFailed)
+	}
+	if took := time.Since(begin); took > 5*time.Second {
+		t.Errorf("took %v, want the wait to be aborted by the context", took)
+	}
+	if s.Calls() != 1 {
+		t.Errorf("calls = %d, want 1", s.Calls())
+	}
+}
+
+func TestRetryWithoutContext(t *testing.T) {
+	s := newFakeService(errFailed)
+	svc := WithRetry(s, WithRetryBackoff(time.Millisecond, time.Millisecond, 1))
+
+	if got, err := svc.Lookup("key"); got != "key" || err != nil {
+		t.Fatalf("Lookup() = %q, %v, want key, nil", got, err)
+	}
+	if s.Calls() != 2 {
+		t.Errorf("calls = %d, want 2", s.Calls())
+	}
+}
+
+func TestRetryNoRetry(t *testing.T) {
+	s := newFakeService(errFailed)
+
+	if err := WithRetry(s).Send(context.Background(), "msg"); err != errFailed {
+		t.Fatalf("Send() = %v, want %v", err, errFailed)
+	}
+	if s.Calls() != 1 {
+		t.Errorf("calls = %d, want 1", s.Calls())
+	}
+}
+
+func TestRetryWithoutError(t *testing.T) {
+	s := newFakeService(errFailed)
+	WithRetry(s).Notify(context.Background())
+
+	if s.Calls() != 1 {
+		t.Errorf("calls = %d, want 1", s.Calls())
+	}
+}
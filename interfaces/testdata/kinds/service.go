@@ -0,0 +1,18 @@
+// Package kinds contains the interfaces whose generated middlewares of all
+// kinds and loggers are compiled and tested by TestKindBehaviour
+package kinds
+
+import "context"
+
+// Service is wrapped by the generated middlewares
+type Service interface {
+	// Call passes the context
+	Call(ctx context.Context) error
+	// Lookup has no context
+	Lookup(key string) (string, error)
+	// Send isn't idempotent
+	// middleware:noretry
+	Send(ctx context.Context, msg string) error
+	// Notify has no error result
+	Notify(ctx context.Context)
+}
@@ -0,0 +1,89 @@
+package kinds
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var errFailed = errors.New("failed")
+
+// fakeService returns the queued errors in order and nil once they are used up
+type fakeService struct {
+	mu       sync.Mutex
+	errs     []error
+	calls    int
+	deadline time.Time
+	// entered receives a value when Call is entered if it isn't nil
+	entered chan struct{}
+	// release blocks Call until it is closed or, in the order of the calls,
+	// receives a value, regardless of the context
+	release chan struct{}
+	// panicValue is raised by Call if it isn't nil
+	panicValue interface{}
+}
+
+func newFakeService(errs ...error) *fakeService {
+	return &fakeService{errs: errs}
+}
+
+// newBlockingService returns a fake whose calls of Call block until released
+func newBlockingService(errs ...error) *fakeService {
+	s := newFakeService(errs...)
+	s.entered = make(chan struct{}, 10)
+	s.release = make(chan struct{})
+
+	return s
+}
+
+func (s *fakeService) Call(ctx context.Context) error {
+	if s.entered != nil {
+		s.entered <- struct{}{}
+	}
+	if s.release != nil {
+		<-s.release
+	}
+	if s.panicValue != nil {
+		panic(s.panicValue)
+	}
+
+	s.mu.Lock()
+	s.deadline, _ = ctx.Deadline()
+	s.mu.Unlock()
+
+	return s.next()
+}
+
+func (s *fakeService) Lookup(key string) (string, error) {
+	return key, s.next()
+}
+
+func (s *fakeService) Send(ctx context.Context, msg string) error {
+	return s.next()
+}
+
+func (s *fakeService) Notify(ctx context.Context) {
+	_ = s.next()
+}
+
+func (s *fakeService) next() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls++
+	if len(s.errs) == 0 {
+		return nil
+	}
+	err := s.errs[0]
+	s.errs = s.errs[1:]
+
+	return err
+}
+
+func (s *fakeService) Calls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.calls
+}
@@ -0,0 +1,23 @@
+package kinds
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Store has parameters and results of the types the templates treat
+// differently
+type Store interface {
+	// Get returns the value of key
+	Get(ctx context.Context, key string) (value []byte, err error)
+	// Put stores value for ttl
+	// middleware:trace key
+	Put(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// List returns up to limit keys with prefix and all of tags
+	List(prefix string, limit int, tags ...string) ([]string, error)
+	// Touch marks id as used at
+	Touch(at time.Time, id fmt.Stringer)
+	// Login returns a token for user
+	Login(ctx context.Context, user string, password string) (token string, err error)
+}
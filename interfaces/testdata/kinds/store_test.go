@@ -0,0 +1,30 @@
+package kinds
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// fakeStore returns err from every method with an error result
+type fakeStore struct {
+	err error
+}
+
+func (s *fakeStore) Get(ctx context.Context, key string) ([]byte, error) {
+	return []byte(key), s.err
+}
+
+func (s *fakeStore) Put(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return s.err
+}
+
+func (s *fakeStore) List(prefix string, limit int, tags ...string) ([]string, error) {
+	return tags, s.err
+}
+
+func (s *fakeStore) Touch(at time.Time, id fmt.Stringer) {}
+
+func (s *fakeStore) Login(ctx context.Context, user string, password string) (string, error) {
+	return "token", s.err
+}
@@ -0,0 +1,122 @@
+package kinds
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTimeout(t *testing.T) {
+	s := newFakeService()
+	s.release = make(chan struct{})
+	defer close(s.release)
+	svc := WithTimeout(s, WithTimeoutTimeout(20*time.Millisecond))
+
+	begin := time.Now()
+	err := svc.Call(context.Background())
+
+	var timeout WithTimeoutErrTimeout
+	if !errors.As(err, &timeout) {
+		t.Fatalf("Call() = %v, want WithTimeoutErrTimeout", err)
+	}
+	if want := (WithTimeoutErrTimeout{Interface: "Service", Method: "Call", Timeout: 20 * time.Millisecond}); timeout != want {
+		t.Errorf("Call() = %#v, want %#v", timeout, want)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Call() = %v, want to wrap context.DeadlineExceeded", err)
+	}
+	if took := time.Since(begin); took > 5*time.Second {
+		t.Errorf("took %v, want to return without waiting for the implementation", took)
+	}
+}
+
+func TestTimeoutMethodTimeout(t *testing.T) {
+	s := newFakeService()
+	s.release = make(chan struct{})
+	defer close(s.release)
+	svc := WithTimeout(s, WithTimeoutTimeout(time.Hour), WithTimeoutMethodTimeout("Call", 20*time.Millisecond))
+
+	var timeout WithTimeoutErrTimeout
+	if err := svc.Call(context.Background()); !errors.As(err, &timeout) || timeout.Timeout != 20*time.Millisecond {
+		t.Fatalf("Call() = %v, want timeout after 20ms", err)
+	}
+}
+
+func TestTimeoutDeadline(t *testing.T) {
+	s := newFakeService(errFailed)
+	svc := WithTimeout(s, WithTimeoutTimeout(time.Minute))
+
+	begin := time.Now()
+	if err := svc.Call(context.Background()); err != errFailed {
+		t.Fatalf("Call() = %v, want %v", err, errFailed)
+	}
+	if s.deadline.Before(begin.Add(time.Minute)) || s.deadline.After(time.Now().Add(time.Minute)) {
+		t.Errorf("deadline = %v, want a minute after the call", s.deadline)
+	}
+}
+
+func TestTimeoutCanceled(t *testing.T) {
+	s := newFakeService()
+	s.release = make(chan struct{})
+	defer close(s.release)
+	svc := WithTimeout(s)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := svc.Call(ctx); err != context.Canceled {
+		t.Fatalf("Call() = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestTimeoutParentDeadline(t *testing.T) {
+	s := newFakeService()
+	s.release = make(chan struct{})
+	defer close(s.release)
+	svc := WithTimeout(s, WithTimeoutTimeout(time.Hour))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := svc.Call(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("Call() = %v, want the plain %v of the caller", err, context.DeadlineExceeded)
+	}
+}
+
+func TestTimeoutPanic(t *testing.T) {
+	s := newFakeService()
+	s.panicValue = "boom"
+	svc := WithTimeout(s)
+
+	defer func() {
+		if recovered := recover(); recovered != "boom" {
+			t.Errorf("recovered %v, want boom", recovered)
+		}
+	}()
+	_ = svc.Call(context.Background())
+	t.Errorf("Call() returned, want panic")
+}
+
+func TestTimeoutPanicAfterTimeout(t *testing.T) {
+	s := newBlockingService()
+	s.panicValue = "boom"
+	svc := WithTimeout(s, WithTimeoutTimeout(20*time.Millisecond))
+
+	var timeout WithTimeoutErrTimeout
+	if err := svc.Call(context.Background()); !errors.As(err, &timeout) {
+		t.Fatalf("Call() = %v, want WithTimeoutErrTimeout", err)
+	}
+
+	// The late panic is dropped instead of crashing the program
+	close(s.release)
+	time.Sleep(20 * time.Millisecond)
+}
+
+func TestTimeoutWithoutContext(t *testing.T) {
+	s := newFakeService(errFailed)
+
+	if got, err := WithTimeout(s).Lookup("key"); got != "key" || err != errFailed {
+		t.Fatalf("Lookup() = %q, %v, want key, %v", got, err, errFailed)
+	}
+}
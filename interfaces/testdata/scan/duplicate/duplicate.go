@@ -0,0 +1,10 @@
+// Package duplicate contains generate markers writing to the same output
+package duplicate
+
+// Store persists values.
+//
+//middleware:generate retry output=store_gen.go
+//middleware:generate timeout output=store_gen.go
+type Store interface {
+	Get(key string) (string, error)
+}
@@ -0,0 +1,14 @@
+// Package multiple contains an interface annotated with several generate
+// markers
+package multiple
+
+import "context"
+
+// Store persists values.
+//
+//middleware:generate retry
+//middleware:generate timeout output=store_timeout.go
+//middleware:generate circuitbreaker
+type Store interface {
+	Get(ctx context.Context, key string) (string, error)
+}
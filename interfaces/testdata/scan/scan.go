@@ -0,0 +1,24 @@
+// Package scan contains interfaces annotated with generate markers
+package scan
+
+import "context"
+
+// Store persists values.
+//
+//middleware:generate logging output=store_gen.go logger=slog
+type Store interface {
+	Get(ctx context.Context, key string) (string, error)
+}
+
+type (
+	// Cache caches values.
+	//middleware:generate logging wrapper=loggingCache redact=[key]
+	Cache interface {
+		Put(key, value string)
+	}
+
+	// Plain isn't annotated.
+	Plain interface {
+		Do()
+	}
+)
@@ -0,0 +1,261 @@
+package interfaces
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout is the timeout of methods without configured timeout
+const DefaultTimeout = 10 * time.Second
+
+// timeouts contains the parsed timeouts of options
+type timeouts struct {
+	// timeout is the default timeout, zero if it isn't configured
+	timeout time.Duration
+	methods map[string]time.Duration
+}
+
+// parseTimeouts returns the default timeout and the timeouts per method name
+// configured by options
+func parseTimeouts(options Options) (*timeouts, error) {
+	t := &timeouts{methods: map[string]time.Duration{}}
+	if options.Timeout != "" {
+		var err error
+		if t.timeout, err = parseTimeout(options.Timeout); err != nil {
+			return nil, fmt.Errorf("Invalid timeout %q: %v", options.Timeout, err)
+		}
+	}
+
+	for method, value := range options.Timeouts {
+		d, err := parseTimeout(value)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid timeout %q of method %q: %v", value, method, err)
+		}
+		t.methods[method] = d
+	}
+
+	return t, nil
+}
+
+func parseTimeout(value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err == nil && d <= 0 {
+		err = fmt.Errorf("timeout must be positive")
+	}
+
+	return d, err
+}
+
+func defaultDuration(d time.Duration, def time.Duration) time.Duration {
+	if d == 0 {
+		return def
+	}
+
+	return d
+}
+
+// checkTimeouts returns an error if a method timeout is configured for a
+// method which none of inters has, which most likely is a typo, or for a
+// method without context parameter, which the timeout can't be applied to
+func checkTimeouts(options Options, inters []*Interface) error {
+	if options.Kind != MiddlewareTimeout {
+		return nil
+	}
+
+	methods := map[string]bool{}
+	withoutContext := map[string]bool{}
+	for _, inter := range inters {
+		for _, f := range inter.Functions {
+			methods[f.Name] = true
+			if f.ContextParam() == nil {
+				withoutContext[f.Name] = true
+			}
+		}
+	}
+
+	unknown := []string{}
+	inapplicable := []string{}
+	for method := range options.Timeouts {
+		if !methods[method] {
+			unknown = append(unknown, method)
+		} else if withoutContext[method] {
+			inapplicable = append(inapplicable, method)
+		}
+	}
+	sort.Strings(unknown)
+	sort.Strings(inapplicable)
+
+	if len(unknown) > 0 {
+		return fmt.Errorf("Timeouts configured for unknown methods: %v", strings.Join(unknown, ", "))
+	}
+	if len(inapplicable) > 0 {
+		return fmt.Errorf("Timeouts configured for methods without context.Context parameter: %v", strings.Join(inapplicable, ", "))
+	}
+
+	return nil
+}
+
+// durationExpr returns d as Go expression in the largest unit dividing it,
+// e.g. "1500 * time.Millisecond"
+func durationExpr(d time.Duration) string {
+	units := []struct {
+		unit time.Duration
+		name string
+	}{
+		{time.Hour, "time.Hour"},
+		{time.Minute, "time.Minute"},
+		{time.Second, "time.Second"},
+		{time.Millisecond, "time.Millisecond"},
+		{time.Microsecond, "time.Microsecond"},
+	}
+	for _, u := range units {
+		if d%u.unit == 0 {
+			return fmt.Sprintf("%d * %v", d/u.unit, u.name)
+		}
+	}
+
+	return fmt.Sprintf("%d * time.Nanosecond", d)
+}
+
+// timeoutTmpl is the template of the timeout middleware. Methods with a
+// context parameter pass a child context with the timeout of the method to
+// the wrapped implementation. Methods whose last result is an error
+// additionally return ErrTimeout as soon as the timeout expires, or the error
+// of the context parameter if it is done first, without waiting for the
+// wrapped implementation to return. The implementation then
+// keeps running in its goroutine until it returns, its results and a panic
+// are dropped. A panic before the timeout is re-raised in the caller. The
+// exported error type is prefixed with the middleware function name like the
+// options.
+var timeoutTmpl = `// Code generated by github.com/hanofzelbri/middleware-generato; DO NOT EDIT
+
+package {{.WrapperPackageName}}
+{{template "imports"}}
+
+{{if .Comment}}{{.Comment}}{{end -}}
+type {{.WrapperStructName}}{{.TypeParamList}} struct {
+    {{wrapperField}} {{.TypeName}}
+    {{optionsField}} {{.WrapperStructName}}Options
+}
+
+// {{.MiddleWareFunctionName}}ErrTimeout is returned if a method doesn't return within its timeout.
+// It wraps context.DeadlineExceeded.
+type {{.MiddleWareFunctionName}}ErrTimeout struct {
+    Interface string
+    Method    string
+    Timeout   time.Duration
+}
+
+func (e {{.MiddleWareFunctionName}}ErrTimeout) Error() string {
+    return e.Interface + "." + e.Method + " timed out after " + e.Timeout.String()
+}
+
+func (e {{.MiddleWareFunctionName}}ErrTimeout) Unwrap() error {
+    return context.DeadlineExceeded
+}
+
+type {{.WrapperStructName}}Options struct {
+    timeout  time.Duration
+    timeouts map[string]time.Duration
+}
+
+// {{.MiddleWareFunctionName}}Option configures the middleware created by {{.MiddleWareFunctionName}}
+type {{.MiddleWareFunctionName}}Option func(*{{.WrapperStructName}}Options)
+
+// {{.MiddleWareFunctionName}}Timeout sets the timeout of methods without own timeout, defaults to {{defaultTimeout}}
+func {{.MiddleWareFunctionName}}Timeout(timeout time.Duration) {{.MiddleWareFunctionName}}Option {
+    return func(o *{{.WrapperStructName}}Options) {
+        o.timeout = timeout
+    }
+}
+
+// {{.MiddleWareFunctionName}}MethodTimeout sets the timeout of method
+func {{.MiddleWareFunctionName}}MethodTimeout(method string, timeout time.Duration) {{.MiddleWareFunctionName}}Option {
+    return func(o *{{.WrapperStructName}}Options) {
+        o.timeouts[method] = timeout
+    }
+}
+
+// {{.MiddleWareFunctionName}} adds timeouts for interface {{.Name}}. Methods returning an error return
+// {{.MiddleWareFunctionName}}ErrTimeout without waiting for the wrapped implementation, which keeps
+// running in its own goroutine until it returns and should stop when its context is done.
+func {{.MiddleWareFunctionName}}{{.TypeParamList}}(wrapper {{.TypeName}}, opts ...{{.MiddleWareFunctionName}}Option) {{.TypeName}} {
+    options := {{.WrapperStructName}}Options{
+        timeout:  {{defaultTimeout}},
+        timeouts: map[string]time.Duration{
+            {{- range .Functions}}{{if .Timeout}}
+            "{{.Name}}": {{durationExpr .Timeout}},
+            {{- end}}{{end}}
+        },
+    }
+    for _, opt := range opts {
+        opt(&options)
+    }
+
+    return &{{.WrapperStructName}}{{.TypeArgList}}{
+        {{wrapperField}}: wrapper,
+        {{optionsField}}: options,
+    }
+}
+
+func (o *{{.WrapperStructName}}Options) timeoutOf(method string) time.Duration {
+    if timeout, ok := o.timeouts[method]; ok {
+        return timeout
+    }
+
+    return o.timeout
+}
+
+{{range $f := .Functions}}
+{{if .Comment}}{{.Comment}}{{end -}}
+func (l *{{$.WrapperStructName}}{{$.TypeArgList}}) {{.Name}}({{range .Params}}{{.Name}} {{.Type.Name}}, {{end}}) ({{range .Res}}{{.Name}} {{.Type.Name}}, {{end}}) {
+    {{- with .ContextParam}}
+    if {{.Name}} == nil {
+        {{.Name}} = context.Background()
+    }
+    timeout := l.{{optionsField}}.timeoutOf("{{$f.Name}}")
+    {{- if $f.ErrorResult}}
+    parent := {{.Name}}
+    {{- end}}
+    {{.Name}}, cancel := context.WithTimeout({{.Name}}, timeout)
+    defer cancel()
+    {{- end}}
+    {{- if and .ContextParam .ErrorResult}}
+
+    call := make(chan func() ({{range $i, $r := .Res}}{{if $i}}, {{end}}{{$r.Type.Name}}{{end}}), 1)
+    go func() {
+        defer func() {
+            if recovered := recover(); recovered != nil {
+                call <- func() ({{range $i, $r := .Res}}{{if $i}}, {{end}}{{$r.Type.Name}}{{end}}) {
+                    panic(recovered)
+                }
+            }
+        }()
+        {{range $i, $r := .Res}}{{if $i}}, {{end}}{{$r.Name}}{{end}} := l.{{wrapperField}}.{{.Name}}({{range $i, $p := .Params}}{{if $i}},{{end}}{{$p.Name}}{{end}}{{if .IsVariadic}}...{{end}})
+        call <- func() ({{range $i, $r := .Res}}{{if $i}}, {{end}}{{$r.Type.Name}}{{end}}) {
+            return {{range $i, $r := .Res}}{{if $i}}, {{end}}{{$r.Name}}{{end}}
+        }
+    }()
+
+    select {
+    case returned := <-call:
+        return returned()
+    case <-{{.ContextParam.Name}}.Done():
+        {{- with .ErrorResult}}
+        {{.Name}} = {{$f.ContextParam.Name}}.Err()
+        // An earlier deadline of the caller isn't reported as timeout
+        if {{.Name}} == context.DeadlineExceeded && parent.Err() == nil {
+            {{.Name}} = {{$.MiddleWareFunctionName}}ErrTimeout{Interface: "{{$.ShortName}}", Method: "{{$f.Name}}", Timeout: timeout}
+        }
+        {{- end}}
+        return
+    }
+    {{- else}}
+
+    {{if .Res}}return{{end}} l.{{wrapperField}}.{{.Name}}({{range $i, $p := .Params}}{{if $i}},{{end}}{{$p.Name}}{{end}}{{if .IsVariadic}}...{{end}})
+    {{- end}}
+}
+{{end}}
+`
@@ -0,0 +1,151 @@
+package interfaces
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// traceAnnotation restricts the parameters recorded as span attributes in a
+// method doc comment, e.g. "// middleware:trace id, size"
+var traceAnnotation = regexp.MustCompile(`middleware:trace\s+([\w\s,]+)`)
+
+// otelAttribute returns the attribute.KeyValue recording p on a span. An
+// empty string is returned for values which aren't recorded.
+func otelAttribute(p Param, redactMode string) string {
+	if p.Redacted && loggable(p) {
+		return fmt.Sprintf("attribute.String(%q, %v)", p.Key(), redactedValue(p, redactMode))
+	}
+
+	switch p.Type.Kind {
+	case KindString:
+		return fmt.Sprintf("attribute.String(%q, %v)", p.Key(), convert(p, "string"))
+	case KindBool:
+		return fmt.Sprintf("attribute.Bool(%q, %v)", p.Key(), convert(p, "bool"))
+	case KindInt, KindUint:
+		return fmt.Sprintf("attribute.Int64(%q, %v)", p.Key(), convert(p, "int64"))
+	case KindFloat:
+		return fmt.Sprintf("attribute.Float64(%q, %v)", p.Key(), convert(p, "float64"))
+	case KindStringer:
+		return fmt.Sprintf("attribute.Stringer(%q, %v)", p.Key(), p.Name)
+	case KindTime, KindDuration:
+		return fmt.Sprintf("attribute.String(%q, %v.String())", p.Key(), p.Name)
+	}
+
+	return ""
+}
+
+// otelAttributes returns the attributes recording the params of f on a span.
+// Only the params named by a trace annotation are recorded if f has one.
+func otelAttributes(f Func, redactMode string) []string {
+	var traced map[string]bool
+	if f.Traced != nil {
+		traced = map[string]bool{}
+		for _, name := range f.Traced {
+			traced[name] = true
+		}
+	}
+
+	attributes := []string{}
+	for _, p := range f.Params {
+		if traced != nil && !traced[p.Key()] {
+			continue
+		}
+		if a := otelAttribute(p, redactMode); a != "" {
+			attributes = append(attributes, a)
+		}
+	}
+
+	return attributes
+}
+
+// tracingTmpl is the template of the OpenTelemetry tracing middleware. Every
+// call is recorded as span named Interface.Method, which is a child of the
+// span in the context parameter if there is one. All parameters of basic
+// types, fmt.Stringer, time.Time and time.Duration are recorded as attributes,
+// unless the method has a trace annotation, then only the named ones are.
+// Returned errors are recorded on the span.
+var tracingTmpl = `// Code generated by github.com/hanofzelbri/middleware-generato; DO NOT EDIT
+
+package {{.WrapperPackageName}}
+{{template "imports"}}
+
+{{if .Comment}}{{.Comment}}{{end -}}
+type {{.WrapperStructName}}{{.TypeParamList}} struct {
+    {{wrapperField}} {{.TypeName}}
+    {{field "tracer"}} trace.Tracer
+}
+
+type {{.WrapperStructName}}Options struct {
+    tracerProvider trace.TracerProvider
+    tracerName     string
+}
+
+// {{.MiddleWareFunctionName}}Option configures the middleware created by {{.MiddleWareFunctionName}}
+type {{.MiddleWareFunctionName}}Option func(*{{.WrapperStructName}}Options)
+
+// {{.MiddleWareFunctionName}}TracerProvider sets the provider of the tracer, defaults to otel.GetTracerProvider()
+func {{.MiddleWareFunctionName}}TracerProvider(tracerProvider trace.TracerProvider) {{.MiddleWareFunctionName}}Option {
+    return func(o *{{.WrapperStructName}}Options) {
+        o.tracerProvider = tracerProvider
+    }
+}
+
+// {{.MiddleWareFunctionName}}TracerName sets the instrumentation name of the tracer, defaults to "{{.WrapperPackageName}}"
+func {{.MiddleWareFunctionName}}TracerName(tracerName string) {{.MiddleWareFunctionName}}Option {
+    return func(o *{{.WrapperStructName}}Options) {
+        o.tracerName = tracerName
+    }
+}
+
+// {{.MiddleWareFunctionName}} adds tracing for interface {{.Name}}
+func {{.MiddleWareFunctionName}}{{.TypeParamList}}(wrapper {{.TypeName}}, opts ...{{.MiddleWareFunctionName}}Option) {{.TypeName}} {
+    options := {{.WrapperStructName}}Options{
+        tracerProvider: otel.GetTracerProvider(),
+        tracerName:     "{{.WrapperPackageName}}",
+    }
+    for _, opt := range opts {
+        opt(&options)
+    }
+
+    return &{{.WrapperStructName}}{{.TypeArgList}}{
+        {{wrapperField}}: wrapper,
+        {{field "tracer"}}: options.tracerProvider.Tracer(options.tracerName),
+    }
+}
+
+{{range $f := .Functions}}
+{{if .Comment}}{{.Comment}}{{end -}}
+func (l *{{$.WrapperStructName}}{{$.TypeArgList}}) {{.Name}}({{range .Params}}{{.Name}} {{.Type.Name}}, {{end}}) ({{range .Res}}{{.Name}} {{.Type.Name}}, {{end}}) {
+    {{- with .ContextParam}}
+    if {{.Name}} == nil {
+        {{.Name}} = context.Background()
+    }
+    {{.Name}}, span := l.{{field "tracer"}}.Start({{.Name}}, "{{$.ShortName}}.{{$f.Name}}"
+    {{- else}}
+    _, span := l.{{field "tracer"}}.Start(context.Background(), "{{$.ShortName}}.{{$f.Name}}"
+    {{- end}}
+    {{- with otelAttributes .}}, trace.WithAttributes(
+        {{- range .}}
+        {{.}},
+        {{- end}}
+    ){{end}})
+    {{- with .ErrorResult}}
+    defer func() {
+        if {{.Name}} != nil {
+            {{- if .Redacted}}
+            span.SetStatus(codes.Error, {{value .}})
+            {{- else}}
+            span.RecordError({{.Name}})
+            span.SetStatus(codes.Error, {{.Name}}.Error())
+            {{- end}}
+        }
+        span.End()
+    }()
+    {{- else}}
+    defer span.End()
+    {{- end}}
+
+    {{if .Res}}return{{end}} l.{{wrapperField}}.{{.Name}}({{range $i, $p := .Params}}{{if $i}},{{end}}{{$p.Name}}{{end}}{{if .IsVariadic}}...{{end}})
+}
+{{end}}
+`
@@ -0,0 +1,169 @@
+package interfaces
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// positionPattern matches the file and line of an error position
+var positionPattern = regexp.MustCompile(`^(.*?):(\d+)(?::\d+)?$`)
+
+const typeCheckMode = packages.NeedName |
+	packages.NeedFiles |
+	packages.NeedImports |
+	packages.NeedDeps |
+	packages.NeedTypes |
+	packages.NeedSyntax |
+	packages.NeedTypesInfo
+
+// TypeCheck type-checks the generated files, keyed by their output path,
+// together with the other files of their packages without writing them.
+// Only errors in the generated files are reported, named after the method
+// they occur in.
+func TypeCheck(options Options, files map[string][]byte) error {
+	overlay := map[string][]byte{}
+	dirs := map[string]bool{}
+	tests := false
+	for path, content := range files {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return err
+		}
+
+		overlay[abs] = content
+		dirs[filepath.Dir(abs)] = true
+		tests = tests || strings.HasSuffix(abs, "_test.go")
+	}
+
+	errs := []string{}
+	for _, dir := range sortedKeys(dirs) {
+		// The directory of a new package only exists in the overlay
+		base := dir
+		for !exists(base) && filepath.Dir(base) != base {
+			base = filepath.Dir(base)
+		}
+		rel, err := filepath.Rel(base, dir)
+		if err != nil {
+			return err
+		}
+
+		pkgs, err := packages.Load(&packages.Config{
+			Mode:       typeCheckMode,
+			Dir:        base,
+			Tests:      tests,
+			BuildFlags: buildFlags(options),
+			Env:        buildEnv(options),
+			Overlay:    overlay,
+		}, "./"+filepath.ToSlash(rel))
+		if err != nil {
+			return fmt.Errorf("Package in %q could not be loaded to type-check the generated middleware: %v", dir, err)
+		}
+
+		seen := map[string]bool{}
+		packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+			for _, e := range pkg.Errors {
+				msg, ok := generatedError(e, overlay)
+				if ok && !seen[msg] {
+					seen[msg] = true
+					errs = append(errs, msg)
+				}
+			}
+		})
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("Generated middleware does not compile:\n\t%v", strings.Join(errs, "\n\t"))
+	}
+
+	return nil
+}
+
+// generatedError formats e if it occurred in one of the generated files
+func generatedError(e packages.Error, files map[string][]byte) (string, bool) {
+	path, line := errorPosition(e.Pos)
+	content, ok := files[path]
+	if !ok {
+		return "", false
+	}
+
+	if method := enclosingMethod(content, line); method != "" {
+		return fmt.Sprintf("%v: method %v: %v", e.Pos, method, e.Msg), true
+	}
+
+	return fmt.Sprintf("%v: %v", e.Pos, e.Msg), true
+}
+
+// errorPosition splits a position like file.go:12:5 into the file and line
+func errorPosition(pos string) (string, int) {
+	m := positionPattern.FindStringSubmatch(pos)
+	if m == nil {
+		return pos, 0
+	}
+
+	line, _ := strconv.Atoi(m[2])
+	return m[1], line
+}
+
+// enclosingMethod returns the name of the method declared in content around
+// line, e.g. Store.Get
+func enclosingMethod(content []byte, line int) string {
+	fset := token.NewFileSet()
+	f, _ := parser.ParseFile(fset, "", content, 0)
+	if f == nil {
+		return ""
+	}
+
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil || len(fn.Recv.List) == 0 {
+			continue
+		}
+		if fset.Position(fn.Pos()).Line > line || fset.Position(fn.End()).Line < line {
+			continue
+		}
+
+		return receiverName(fn.Recv.List[0].Type) + "." + fn.Name.Name
+	}
+
+	return ""
+}
+
+func receiverName(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.StarExpr:
+		return receiverName(expr.X)
+	case *ast.IndexExpr:
+		return receiverName(expr.X)
+	case *ast.IndexListExpr:
+		return receiverName(expr.X)
+	case *ast.Ident:
+		return expr.Name
+	}
+
+	return ""
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,77 @@
+package interfaces
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypeCheck(t *testing.T) {
+	inter, err := BuildInterface(Options{
+		Query:                              "github.com/hanofzelbri/middleware-generator/interfaces.CompositeParamsInterface",
+		Wrapper:                            "interfaces.zzTypeCheck",
+		MiddlewareFunctionName:             "WithZZTypeCheck",
+		EmptyFunctionParamNamePrefix:       "param",
+		EmptyFunctionReturnParamNamePrefix: "ret",
+		Logger:                             LoggerSlog,
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	valid, err := InterfaceWrapperTemplate(inter)
+	if !assert.NoError(t, err) {
+		return
+	}
+	broken := []byte(strings.Replace(string(valid), "l.wrapper.Slice(", "l.wrapper.Missing(", 1))
+
+	tests := []struct {
+		name    string
+		path    string
+		content []byte
+		wantErr []string
+	}{
+		{
+			name:    "valid",
+			path:    "zz_typecheck_test.go",
+			content: valid,
+		},
+		{
+			name:    "undefined method",
+			path:    "zz_typecheck_test.go",
+			content: broken,
+			wantErr: []string{"zz_typecheck_test.go:", "method zzTypeCheck.Slice:", "Missing"},
+		},
+		{
+			name:    "new package",
+			path:    filepath.Join("testdata", "typecheck", "gen.go"),
+			content: []byte("package typecheck\n\nimport \"time\"\n\nvar x = time.Now() + 1\n"),
+			wantErr: []string{"gen.go:5:"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := TypeCheck(Options{}, map[string][]byte{tt.path: tt.content})
+			if len(tt.wantErr) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				for _, e := range tt.wantErr {
+					assert.Contains(t, err.Error(), e)
+				}
+			}
+		})
+	}
+}
+
+func TestErrorPosition(t *testing.T) {
+	path, line := errorPosition("/tmp/a:b/gen.go:12:5")
+	assert.Equal(t, "/tmp/a:b/gen.go", path)
+	assert.Equal(t, 12, line)
+
+	path, line = errorPosition("-")
+	assert.Equal(t, "-", path)
+	assert.Equal(t, 0, line)
+}